@@ -7,7 +7,6 @@ toolchain go1.23.2
 require (
 	github.com/go-pdf/fpdf v0.9.0
 	golang.org/x/crypto v0.35.0
+	golang.org/x/net v0.36.0
 	golang.org/x/text v0.22.0
 )
-
-require golang.org/x/net v0.36.0 // indirect
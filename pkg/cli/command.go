@@ -1,13 +1,19 @@
 package cli
 
-import "slices"
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+)
 
 type Command struct {
 	Keyword     string
-	Usage       string
 	Description string
 	Aliases     []string
-	Do          func(args ...string) (exitcode int)
+	Args        []*Arg  // Positional arguments (optional arguments must come last).
+	Flags       []*Flag // Flags (ex: "--private" or "--timeout=10s").
+	Do          func(in *Input) (exitcode int)
 }
 
 func Index(keyword string, commands []*Command) *Command {
@@ -18,3 +24,41 @@ func Index(keyword string, commands []*Command) *Command {
 	}
 	return nil
 }
+
+// Returns the flag with the given name (or nil if not found).
+func (cmd *Command) Flag(name string) *Flag {
+	for _, flag := range cmd.Flags {
+		if flag.Name == name {
+			return flag
+		}
+	}
+	return nil
+}
+
+// Holds a set of commands (and the name of the executable, used in help messages).
+type App struct {
+	Name     string // Executable name (ex: "nubio").
+	Commands []*Command
+}
+
+// Parses the arguments and executes the corresponding command.
+// Usage errors are reported consistently (with a hint to the command help).
+func (app *App) Exec(keyword string, args ...string) (exitcode int) {
+	cmd := Index(keyword, app.Commands)
+	if cmd == nil {
+		fmt.Printf("Unknown command: %q.\n", keyword)
+		fmt.Printf("Use %q to list available commands.\n", app.Name+" help")
+		return 1
+	}
+
+	in, err := cmd.Parse(args...)
+	if errors.Is(err, ErrHelp) {
+		WriteCommandHelp(os.Stdout, app.Name, cmd)
+		return 0
+	} else if err != nil {
+		fmt.Printf("Invalid usage of %q: %s.\n", cmd.Keyword, err)
+		fmt.Printf("Use %q for more information.\n", app.Name+" help "+cmd.Keyword)
+		return 1
+	}
+	return cmd.Do(in)
+}
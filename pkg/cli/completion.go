@@ -0,0 +1,245 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// Shells supported by WriteCompletion.
+var Shells = []string{"bash", "zsh", "fish"}
+
+// Writes a shell completion script for the commands of the app.
+// Command keywords, aliases, flags, argument choices and file paths are completed.
+func WriteCompletion(w io.Writer, app *App, shell string) error {
+	switch shell {
+	case "bash":
+		writeBashCompletion(w, app)
+	case "zsh":
+		writeZshCompletion(w, app)
+	case "fish":
+		writeFishCompletion(w, app)
+	default:
+		return fmt.Errorf("unsupported shell: %q", shell)
+	}
+	return nil
+}
+
+// Note: Aliases that look like flags or patterns (ex: "--help" or "?") are not suggested.
+var completableKeyword = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_-]*$`)
+
+func completableKeywords(cmd *Command) (keywords []string) {
+	for _, keyword := range append([]string{cmd.Keyword}, cmd.Aliases...) {
+		if completableKeyword.MatchString(keyword) {
+			keywords = append(keywords, keyword)
+		}
+	}
+	return keywords
+}
+
+func shellQuote(v string) string { return "'" + strings.ReplaceAll(v, "'", `'\''`) + "'" }
+
+func shellFunc(appName, name string) string {
+	return "_" + strings.NewReplacer("-", "_", ".", "_").Replace(appName) + "_" + name
+}
+
+func writeBashCompletion(w io.Writer, app *App) {
+	fmt.Fprintf(w, "# bash completion for %s (generated by %q).\n\n", app.Name, app.Name+" completion bash")
+
+	// Suggest values of positional arguments (by position) and flags (by name).
+	fmt.Fprintf(w, "%s() {\n\tcase \"$1 $2\" in\n", shellFunc(app.Name, "values"))
+	for _, cmd := range app.Commands {
+		for i, arg := range cmd.Args {
+			writeBashValues(w, fmt.Sprintf("%s %d", cmd.Keyword, i), arg.Choices, arg.Path)
+		}
+		for _, flag := range cmd.Flags {
+			writeBashValues(w, cmd.Keyword+" --"+flag.Name, flag.Choices, flag.Path)
+		}
+	}
+	fmt.Fprintf(w, "\tesac\n}\n\n")
+
+	// List flags (and flags that expect a value).
+	fmt.Fprintf(w, "%s() {\n\tcase \"$1\" in\n", shellFunc(app.Name, "flags"))
+	for _, cmd := range app.Commands {
+		flags := []string{"--help"}
+		for _, flag := range cmd.Flags {
+			flags = append(flags, "--"+flag.Name)
+		}
+		fmt.Fprintf(w, "\t%s) echo %s ;;\n", shellQuote(cmd.Keyword), shellQuote(strings.Join(flags, " ")))
+	}
+	fmt.Fprintf(w, "\tesac\n}\n\n")
+	fmt.Fprintf(w, "%s() {\n\tcase \"$1 $2\" in\n", shellFunc(app.Name, "takes_value"))
+	for _, cmd := range app.Commands {
+		for _, flag := range cmd.Flags {
+			if flag.kind() != KindBool {
+				fmt.Fprintf(w, "\t%s) return 0 ;;\n", shellQuote(cmd.Keyword+" --"+flag.Name))
+			}
+		}
+	}
+	fmt.Fprintf(w, "\tesac\n\treturn 1\n}\n\n")
+
+	// Complete command keywords, then flags and arguments of the command.
+	// Note: Bash splits "--flag=value" into 3 words ("--flag", "=" and "value").
+	keywords := []string{}
+	fmt.Fprintf(w, "%s() {\n", shellFunc(app.Name, "main"))
+	fmt.Fprintf(w, "\tlocal cur=\"${COMP_WORDS[COMP_CWORD]}\" prev=\"${COMP_WORDS[COMP_CWORD-1]}\"\n")
+	fmt.Fprintf(w, "\tCOMPREPLY=()\n")
+	fmt.Fprintf(w, "\tif [[ $COMP_CWORD -eq 1 ]]; then\n")
+	for _, cmd := range app.Commands {
+		keywords = append(keywords, completableKeywords(cmd)...)
+	}
+	fmt.Fprintf(w, "\t\tCOMPREPLY=($(compgen -W %s -- \"$cur\"))\n", shellQuote(strings.Join(keywords, " ")))
+	fmt.Fprintf(w, "\t\treturn\n\tfi\n\n")
+	fmt.Fprintf(w, "\tlocal cmd=\"${COMP_WORDS[1]}\"\n\tcase \"$cmd\" in\n")
+	for _, cmd := range app.Commands {
+		if len(cmd.Aliases) > 0 {
+			patterns := []string{}
+			for _, alias := range cmd.Aliases {
+				patterns = append(patterns, shellQuote(alias))
+			}
+			fmt.Fprintf(w, "\t%s) cmd=%s ;;\n", strings.Join(patterns, "|"), shellQuote(cmd.Keyword))
+		}
+	}
+	fmt.Fprintf(w, "\tesac\n\n")
+	fmt.Fprintf(w, "\tif [[ $cur == = ]]; then\n\t\tcur=\"\"\n\telif [[ $prev == = ]]; then\n\t\tprev=\"${COMP_WORDS[COMP_CWORD-2]}\"\n\tfi\n")
+	fmt.Fprintf(w, "\tif [[ $prev == -* ]] && %s \"$cmd\" \"$prev\"; then\n", shellFunc(app.Name, "takes_value"))
+	fmt.Fprintf(w, "\t\t%s \"$cmd\" \"$prev\" \"$cur\"\n\t\treturn\n\tfi\n", shellFunc(app.Name, "values"))
+	fmt.Fprintf(w, "\tif [[ $cur == -* ]]; then\n")
+	fmt.Fprintf(w, "\t\tCOMPREPLY=($(compgen -W \"$(%s \"$cmd\")\" -- \"$cur\"))\n\t\treturn\n\tfi\n\n", shellFunc(app.Name, "flags"))
+	fmt.Fprintf(w, "\t# Count positional arguments before the current one.\n")
+	fmt.Fprintf(w, "\tlocal i pos=0 word\n")
+	fmt.Fprintf(w, "\tfor ((i = 2; i < COMP_CWORD; i++)); do\n")
+	fmt.Fprintf(w, "\t\tword=\"${COMP_WORDS[i]}\"\n")
+	fmt.Fprintf(w, "\t\tif [[ $word == = ]]; then\n\t\t\t((i++))\n")
+	fmt.Fprintf(w, "\t\telif [[ $word == -* ]]; then\n")
+	fmt.Fprintf(w, "\t\t\tif %s \"$cmd\" \"$word\" && [[ ${COMP_WORDS[i+1]} != = ]]; then\n\t\t\t\t((i++))\n\t\t\tfi\n", shellFunc(app.Name, "takes_value"))
+	fmt.Fprintf(w, "\t\telse\n\t\t\t((pos++))\n\t\tfi\n\tdone\n")
+	fmt.Fprintf(w, "\t%s \"$cmd\" \"$pos\" \"$cur\"\n}\n\n", shellFunc(app.Name, "values"))
+	fmt.Fprintf(w, "complete -F %s %s\n", shellFunc(app.Name, "main"), app.Name)
+}
+
+func writeBashValues(w io.Writer, key string, choices []string, path bool) {
+	switch {
+	case len(choices) > 0:
+		fmt.Fprintf(w, "\t%s) COMPREPLY=($(compgen -W %s -- \"$3\")) ;;\n", shellQuote(key), shellQuote(strings.Join(choices, " ")))
+	case path:
+		fmt.Fprintf(w, "\t%s) compopt -o filenames 2>/dev/null; COMPREPLY=($(compgen -f -- \"$3\")) ;;\n", shellQuote(key))
+	}
+}
+
+// Escapes characters that have a special meaning in zsh completion specs.
+func zshEscape(v string) string {
+	return strings.NewReplacer(`\`, `\\`, ":", `\:`, "[", `\[`, "]", `\]`).Replace(v)
+}
+
+func zshAction(choices []string, path bool) string {
+	switch {
+	case len(choices) > 0:
+		return "(" + strings.Join(choices, " ") + ")"
+	case path:
+		return "_files"
+	}
+	return " "
+}
+
+func writeZshCompletion(w io.Writer, app *App) {
+	fmt.Fprintf(w, "#compdef %s\n# zsh completion for %s (generated by %q).\n\n", app.Name, app.Name, app.Name+" completion zsh")
+	fmt.Fprintf(w, "%s() {\n", shellFunc(app.Name, "main"))
+	fmt.Fprintf(w, "\tlocal context state state_descr line\n\ttypeset -A opt_args\n")
+	fmt.Fprintf(w, "\t_arguments -C '1:command:->command' '*::argument:->argument'\n")
+	fmt.Fprintf(w, "\tcase $state in\n\tcommand)\n\t\tlocal -a commands=(\n")
+	for _, cmd := range app.Commands {
+		for _, keyword := range completableKeywords(cmd) {
+			fmt.Fprintf(w, "\t\t\t%s\n", shellQuote(zshEscape(keyword)+":"+cmd.Description))
+		}
+	}
+	fmt.Fprintf(w, "\t\t)\n\t\t_describe -t commands command commands\n\t\t;;\n")
+	fmt.Fprintf(w, "\targument)\n\t\tcase $words[1] in\n")
+	for _, cmd := range app.Commands {
+		patterns := []string{}
+		for _, keyword := range append([]string{cmd.Keyword}, cmd.Aliases...) {
+			patterns = append(patterns, shellQuote(keyword))
+		}
+		specs := []string{"--help[Show help.]"}
+		for _, flag := range cmd.Flags {
+			spec := "--" + flag.Name
+			if flag.kind() != KindBool {
+				spec += "="
+			}
+			spec += "[" + zshEscape(flag.Description) + "]"
+			if flag.kind() != KindBool {
+				spec += ":" + flag.Name + ":" + zshAction(flag.Choices, flag.Path)
+			}
+			specs = append(specs, spec)
+		}
+		for i, arg := range cmd.Args {
+			sep := ":"
+			if arg.isOptional() {
+				sep = "::"
+			}
+			specs = append(specs, fmt.Sprintf("%d%s%s:%s", i+1, sep, zshEscape(arg.Name), zshAction(arg.Choices, arg.Path)))
+		}
+		fmt.Fprintf(w, "\t\t%s)\n\t\t\t_arguments", strings.Join(patterns, "|"))
+		for _, spec := range specs {
+			fmt.Fprintf(w, " \\\n\t\t\t\t%s", shellQuote(spec))
+		}
+		fmt.Fprintf(w, "\n\t\t\t;;\n")
+	}
+	fmt.Fprintf(w, "\t\tesac\n\t\t;;\n\tesac\n}\n\n")
+	fmt.Fprintf(w, "%s \"$@\"\n", shellFunc(app.Name, "main"))
+}
+
+func writeFishCompletion(w io.Writer, app *App) {
+	fmt.Fprintf(w, "# fish completion for %s (generated by %q).\n\n", app.Name, app.Name+" completion fish")
+
+	// Print the position of the current argument (flags and their values are skipped).
+	// Flags expecting a value are provided as arguments (ex: "--out").
+	position := shellFunc(app.Name, "position")
+	fmt.Fprintf(w, "function %s\n", position)
+	fmt.Fprintf(w, "    set -l tokens (commandline -opc)\n")
+	fmt.Fprintf(w, "    set -l n 0\n    set -l skip 0\n")
+	fmt.Fprintf(w, "    for token in $tokens[3..-1]\n")
+	fmt.Fprintf(w, "        if test $skip -eq 1\n            set skip 0\n")
+	fmt.Fprintf(w, "        else if contains -- $token $argv\n            set skip 1\n")
+	fmt.Fprintf(w, "        else if not string match -q -- '-*' $token\n            set n (math $n + 1)\n")
+	fmt.Fprintf(w, "        end\n    end\n    echo $n\nend\n\n")
+
+	fmt.Fprintf(w, "complete -c %s -f\n", app.Name)
+	for _, cmd := range app.Commands {
+		for _, keyword := range completableKeywords(cmd) {
+			fmt.Fprintf(w, "complete -c %s -n __fish_use_subcommand -a %s -d %s\n", app.Name, shellQuote(keyword), shellQuote(cmd.Description))
+		}
+	}
+	for _, cmd := range app.Commands {
+		fmt.Fprintf(w, "\n# %s\n", cmd.Keyword)
+		seen := "__fish_seen_subcommand_from " + strings.Join(completableKeywords(cmd), " ")
+		fmt.Fprintf(w, "complete -c %s -n %s -l help -d 'Show help.'\n", app.Name, shellQuote(seen))
+		valueFlags := []string{}
+		for _, flag := range cmd.Flags {
+			opts := ""
+			switch {
+			case flag.kind() == KindBool:
+			case len(flag.Choices) > 0:
+				opts = " -x -a " + shellQuote(strings.Join(flag.Choices, " "))
+			case flag.Path:
+				opts = " -r -F"
+			default:
+				opts = " -x"
+			}
+			if flag.kind() != KindBool {
+				valueFlags = append(valueFlags, "--"+flag.Name)
+			}
+			fmt.Fprintf(w, "complete -c %s -n %s -l %s%s -d %s\n", app.Name, shellQuote(seen), flag.Name, opts, shellQuote(flag.Description))
+		}
+		for i, arg := range cmd.Args {
+			cond := fmt.Sprintf("%s; and test (%s) -eq %d", seen, strings.Join(append([]string{position}, valueFlags...), " "), i)
+			switch {
+			case len(arg.Choices) > 0:
+				fmt.Fprintf(w, "complete -c %s -n %s -a %s\n", app.Name, shellQuote(cond), shellQuote(strings.Join(arg.Choices, " ")))
+			case arg.Path:
+				fmt.Fprintf(w, "complete -c %s -n %s -F\n", app.Name, shellQuote(cond))
+			}
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+// Note: Flags are listed in the command help only if there are more.
+const maxUsageLineFlags = 5
+
+// Returns the usage line of the command (ex: "export $FORMAT $INPUT_PATH [--private]").
+func (cmd *Command) UsageLine() string {
+	parts := []string{cmd.Keyword}
+	for _, arg := range cmd.Args {
+		placeholder := "$" + strings.ToUpper(strings.ReplaceAll(arg.Name, "-", "_"))
+		if arg.isOptional() {
+			placeholder = "[" + placeholder + "]"
+		}
+		parts = append(parts, placeholder)
+	}
+	if len(cmd.Flags) > maxUsageLineFlags {
+		return strings.Join(append(parts, "[flags]"), " ")
+	}
+	for _, flag := range cmd.Flags {
+		parts = append(parts, "["+flag.usage()+"]")
+	}
+	return strings.Join(parts, " ")
+}
+
+// Returns the flag as shown in the usage line (ex: "--private", "--out=$VALUE" or "--timeout=$DURATION").
+func (flag *Flag) usage() string {
+	switch flag.kind() {
+	case KindBool:
+		return "--" + flag.Name
+	case KindString:
+		return "--" + flag.Name + "=$VALUE"
+	}
+	return "--" + flag.Name + "=$" + strings.ToUpper(flag.kind())
+}
+
+// Writes the list of available commands.
+func WriteCommandList(w io.Writer, appName string, commands []*Command) {
+	fmt.Fprintf(w, "Available commands:\n")
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "- \x1b[33m%-20s\x1b[0m %s\n", cmd.Keyword, cmd.Description)
+	}
+	fmt.Fprintf(w, "Use %q for more information about a command.\n", appName+" help $COMMAND")
+}
+
+// Writes the usage, arguments and flags of the command.
+func WriteCommandHelp(w io.Writer, appName string, cmd *Command) {
+	fmt.Fprintf(w, "Usage: %s %s\n\n", appName, cmd.UsageLine())
+	fmt.Fprintf(w, "%s\n", cmd.Description)
+	if len(cmd.Aliases) > 0 {
+		fmt.Fprintf(w, "\nAliases: %s\n", strings.Join(cmd.Aliases, ", "))
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	if len(cmd.Args) > 0 {
+		fmt.Fprintf(tw, "\nArguments:\n")
+		for _, arg := range cmd.Args {
+			fmt.Fprintf(tw, "  %s\t%s\n", arg.Name, describe(arg.Description, arg.Default, arg.Choices))
+		}
+	}
+	if len(cmd.Flags) > 0 {
+		fmt.Fprintf(tw, "\nFlags:\n")
+		for _, flag := range cmd.Flags {
+			fmt.Fprintf(tw, "  %s\t%s\n", flag.usage(), describe(flag.Description, flag.Default, flag.Choices))
+		}
+	}
+	tw.Flush()
+}
+
+func describe(description, fallback string, choices []string) string {
+	description = strings.TrimSuffix(description, ".")
+	if len(choices) > 0 {
+		description += " (one of: " + strings.Join(choices, ", ") + ")"
+	}
+	if fallback != "" {
+		description += " (default: " + fallback + ")"
+	}
+	if description = strings.TrimSpace(description); description != "" {
+		description += "."
+	}
+	return description
+}
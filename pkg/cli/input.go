@@ -0,0 +1,206 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Value types of flags.
+const (
+	KindString   = "string"
+	KindBool     = "bool"
+	KindInt      = "int"
+	KindFloat    = "float"
+	KindDuration = "duration"
+)
+
+// A positional argument.
+type Arg struct {
+	Name        string   // Used in help and error messages (ex: "format" is shown as "$FORMAT").
+	Description string   // Optional.
+	Default     string   // Optional: Value used when the argument is not provided.
+	Optional    bool     // Set to true if the argument may be omitted (implied by a default value).
+	Choices     []string // Optional: Allowed values.
+	Path        bool     // Set to true if the argument is a file path (used for shell completion).
+	Validate    func(v string) error
+}
+
+func (arg *Arg) isOptional() bool { return arg.Optional || arg.Default != "" }
+
+// A flag (ex: "--private", "--timeout=10s" or "--timeout 10s").
+type Flag struct {
+	Name        string // Without leading dashes (ex: "private").
+	Kind        string // One of: "string" (default), "bool", "int", "float", "duration".
+	Description string
+	Default     string   // Optional: Default value (as provided on the command line, ex: "10s").
+	Choices     []string // Optional: Allowed values.
+	IgnoreCase  bool     // Set to true to accept choices in any case (ex: "letter" for "Letter").
+	Path        bool     // Set to true if the value is a file path (used for shell completion).
+	Validate    func(v string) error
+}
+
+func (flag *Flag) kind() string {
+	if flag.Kind == "" {
+		return KindString
+	}
+	return flag.Kind
+}
+
+// Returned by Command.Parse when "--help" (or "-h") is provided.
+var ErrHelp = errors.New("help requested")
+
+// Holds parsed arguments and flags.
+type Input struct {
+	cmd   *Command
+	args  map[string]string
+	flags map[string]string
+}
+
+// Parses arguments and flags.
+// Flags may be provided before, after or between positional arguments,
+// and "--" marks the end of flags.
+func (cmd *Command) Parse(args ...string) (*Input, error) {
+	in := &Input{cmd: cmd, args: map[string]string{}, flags: map[string]string{}}
+	positional := []string{}
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "--":
+			positional = append(positional, args[i+1:]...)
+			i = len(args)
+			continue
+		case arg == "--help" || arg == "-h":
+			return nil, ErrHelp
+		case !strings.HasPrefix(arg, "-") || arg == "-":
+			positional = append(positional, arg) // Note: "-" is a valid argument (ex: for stdin).
+			continue
+		}
+
+		// Parse flag.
+		name, value, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		flag := cmd.Flag(name)
+		if flag == nil {
+			return nil, fmt.Errorf("unknown flag: %q", "--"+name)
+		}
+		if _, ok := in.flags[name]; ok {
+			return nil, fmt.Errorf("flag provided more than once: %q", "--"+name)
+		}
+		switch {
+		case hasValue:
+		case flag.kind() == KindBool:
+			value = "true"
+		case i+1 < len(args):
+			i++
+			value = args[i]
+		default:
+			return nil, fmt.Errorf("missing value for flag %q", "--"+name)
+		}
+		if err := checkValue(value, flag.kind(), flag.Choices, flag.IgnoreCase, flag.Validate); err != nil {
+			return nil, fmt.Errorf("invalid value for flag %q: %w", "--"+name, err)
+		}
+		in.flags[name] = value
+	}
+
+	// Assign positional arguments.
+	if len(positional) > len(cmd.Args) {
+		return nil, fmt.Errorf("too many arguments: %q", positional[len(cmd.Args):])
+	}
+	for i, arg := range cmd.Args {
+		if i >= len(positional) {
+			if !arg.isOptional() {
+				return nil, fmt.Errorf("missing argument: %s", arg.Name)
+			}
+			in.args[arg.Name] = arg.Default
+			continue
+		}
+		if err := checkValue(positional[i], KindString, arg.Choices, false, arg.Validate); err != nil {
+			return nil, fmt.Errorf("invalid argument %s: %w", arg.Name, err)
+		}
+		in.args[arg.Name] = positional[i]
+	}
+	return in, nil
+}
+
+func checkValue(v, kind string, choices []string, ignoreCase bool, validate func(string) error) error {
+	var err error
+	switch kind {
+	case KindBool:
+		_, err = strconv.ParseBool(v)
+	case KindInt:
+		_, err = strconv.Atoi(v)
+	case KindFloat:
+		_, err = strconv.ParseFloat(v, 64)
+	case KindDuration:
+		_, err = time.ParseDuration(v)
+	}
+	if err != nil {
+		return fmt.Errorf("not a valid %s: %q", kind, v)
+	}
+	isChoice := func(choice string) bool { return choice == v || ignoreCase && strings.EqualFold(choice, v) }
+	if len(choices) > 0 && !slices.ContainsFunc(choices, isChoice) {
+		return fmt.Errorf("%q is not one of: %s", v, strings.Join(choices, ", "))
+	}
+	if validate != nil {
+		return validate(v)
+	}
+	return nil
+}
+
+// Returns the value of the positional argument (or its default value).
+// Panics if the command has no such argument.
+func (in *Input) Arg(name string) string {
+	v, ok := in.args[name]
+	if !ok {
+		panic(fmt.Sprintf("cli: command %q has no argument %q", in.cmd.Keyword, name))
+	}
+	return v
+}
+
+// Returns the raw value of the flag (or its default value).
+// Panics if the command has no such flag.
+func (in *Input) String(name string) string { return in.flag(name, "") }
+
+// Reports whether the flag was explicitly provided.
+func (in *Input) IsSet(name string) bool {
+	in.flag(name, "")
+	_, ok := in.flags[name]
+	return ok
+}
+
+func (in *Input) Bool(name string) bool {
+	v, _ := strconv.ParseBool(in.flag(name, KindBool))
+	return v
+}
+
+func (in *Input) Int(name string) int {
+	v, _ := strconv.Atoi(in.flag(name, KindInt))
+	return v
+}
+
+func (in *Input) Float(name string) float64 {
+	v, _ := strconv.ParseFloat(in.flag(name, KindFloat), 64)
+	return v
+}
+
+func (in *Input) Duration(name string) time.Duration {
+	v, _ := time.ParseDuration(in.flag(name, KindDuration))
+	return v
+}
+
+// Note: Values are validated on parse (so conversion errors can be ignored by callers).
+func (in *Input) flag(name, kind string) string {
+	flag := in.cmd.Flag(name)
+	if flag == nil {
+		panic(fmt.Sprintf("cli: command %q has no flag %q", in.cmd.Keyword, name))
+	} else if kind != "" && flag.kind() != kind {
+		panic(fmt.Sprintf("cli: flag %q of command %q is not a %s", name, in.cmd.Keyword, kind))
+	}
+	if v, ok := in.flags[name]; ok {
+		return v
+	}
+	return flag.Default
+}
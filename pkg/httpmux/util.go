@@ -1,9 +1,13 @@
 package httpmux
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"io"
 	"log/slog"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -92,3 +96,17 @@ func CSSHandler(v []byte) http.HandlerFunc {
 		w.Write(v)
 	}
 }
+
+// Serves the given content with caching headers (Cache-Control and ETag),
+// conditional requests (ex: "If-None-Match") are supported.
+func CachedHandler(v []byte, contentType string, maxAge time.Duration) http.HandlerFunc {
+	sum := sha256.Sum256(v)
+	etag := `"` + hex.EncodeToString(sum[:8]) + `"`
+	cacheControl := "public, max-age=" + strconv.Itoa(int(maxAge.Seconds()))
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", contentType)
+		w.Header().Set("Cache-Control", cacheControl)
+		w.Header().Set("ETag", etag)
+		http.ServeContent(w, r, "", time.Time{}, bytes.NewReader(v))
+	}
+}
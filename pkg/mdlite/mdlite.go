@@ -0,0 +1,208 @@
+// Package mdlite implements a safe subset of Markdown meant for short texts
+// like descriptions.
+//
+// Supported syntax:
+//   - Paragraphs (separated by an empty line).
+//   - Bullet lists (lines starting with "- " or "* ").
+//   - Bold ("**bold**"), emphasis ("*emphasis*" or "_emphasis_") and code ("`code`").
+//   - Links ("[label](https://example.com)").
+//
+// Anything else is rendered as literal text.
+package mdlite
+
+import (
+	"strings"
+	"unicode"
+)
+
+type Document struct {
+	Blocks []*Block
+}
+
+type BlockKind int
+
+const (
+	BlockParagraph BlockKind = iota
+	BlockList
+)
+
+// A paragraph holds a single item, a list holds one item per bullet point.
+type Block struct {
+	Kind  BlockKind
+	Items [][]Span
+}
+
+// Holds a piece of inline text and its style.
+type Span struct {
+	Text     string
+	Bold     bool
+	Emphasis bool
+	Code     bool
+	URL      string // Sanitized link URL (empty if not a link).
+}
+
+func Parse(src string) *Document {
+	doc := &Document{}
+	src = strings.ReplaceAll(src, "\r\n", "\n")
+	for _, chunk := range strings.Split(src, "\n\n") {
+		var paragraph []string
+		var list *Block
+		flushParagraph := func() {
+			if len(paragraph) > 0 {
+				text := strings.Join(paragraph, " ")
+				doc.Blocks = append(doc.Blocks, &Block{Kind: BlockParagraph, Items: [][]Span{parseInline(text)}})
+				paragraph = nil
+			}
+		}
+
+		for _, line := range strings.Split(chunk, "\n") {
+			line = strings.TrimSpace(line)
+			if line == "" {
+				continue
+			}
+			if item, ok := cutBullet(line); ok {
+				flushParagraph()
+				if list == nil {
+					list = &Block{Kind: BlockList}
+					doc.Blocks = append(doc.Blocks, list)
+				}
+				list.Items = append(list.Items, parseInline(item))
+				continue
+			}
+			if list != nil {
+				// Lines directly following a bullet point are part of it.
+				i := len(list.Items) - 1
+				list.Items[i] = append(list.Items[i], parseInline(" "+line)...)
+				continue
+			}
+			paragraph = append(paragraph, line)
+		}
+		flushParagraph()
+	}
+	return doc
+}
+
+func cutBullet(line string) (item string, ok bool) {
+	for _, prefix := range []string{"- ", "* "} {
+		if item, ok := strings.CutPrefix(line, prefix); ok {
+			return strings.TrimSpace(item), true
+		}
+	}
+	return line, false
+}
+
+func parseInline(text string) (spans []Span) {
+	var style Span
+	var emphasisMarker byte
+	buf := &strings.Builder{}
+	flush := func() {
+		if buf.Len() > 0 {
+			span := style
+			span.Text = buf.String()
+			spans = append(spans, span)
+			buf.Reset()
+		}
+	}
+
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		rest := text[i:]
+		switch {
+		case c == '\\' && i+1 < len(text) && strings.IndexByte("\\*_`[]()", text[i+1]) >= 0:
+			// Escaped character.
+			buf.WriteByte(text[i+1])
+			i++
+		case c == '`':
+			end := strings.IndexByte(rest[1:], '`')
+			if end <= 0 {
+				buf.WriteByte(c)
+				continue
+			}
+			flush()
+			spans = append(spans, Span{Text: rest[1 : end+1], Bold: style.Bold, Emphasis: style.Emphasis, Code: true})
+			i += end + 1
+		case c == '[':
+			label, rawURL, size, ok := cutLink(rest)
+			if !ok {
+				buf.WriteByte(c)
+				continue
+			}
+			flush()
+			safeURL := SanitizeURL(rawURL)
+			for _, span := range parseInline(label) {
+				span.Bold = span.Bold || style.Bold
+				span.Emphasis = span.Emphasis || style.Emphasis
+				span.URL = safeURL
+				spans = append(spans, span)
+			}
+			i += size - 1
+		case strings.HasPrefix(rest, "**"):
+			// Opening markers must be closed.
+			if !style.Bold && !strings.Contains(rest[2:], "**") {
+				buf.WriteString("**")
+				i++
+				continue
+			}
+			flush()
+			style.Bold = !style.Bold
+			i++
+		case c == '*' || c == '_':
+			isOpening := !style.Emphasis && strings.IndexByte(rest[1:], c) >= 0
+			isClosing := style.Emphasis && c == emphasisMarker
+			// Underscores within words (ex: "snake_case") are literal.
+			if c == '_' {
+				isOpening = isOpening && (i == 0 || !isWordChar(text[i-1]))
+				isClosing = isClosing && (i+1 == len(text) || !isWordChar(text[i+1]))
+			}
+			if !isOpening && !isClosing {
+				buf.WriteByte(c)
+				continue
+			}
+			flush()
+			style.Emphasis = isOpening
+			emphasisMarker = c
+		default:
+			buf.WriteByte(c)
+		}
+	}
+	flush()
+	return spans
+}
+
+func isWordChar(c byte) bool {
+	return c >= 0x80 || unicode.IsLetter(rune(c)) || unicode.IsDigit(rune(c))
+}
+
+// Parses a link of the form "[label](url)" at the start of the given text.
+func cutLink(text string) (label, url string, size int, ok bool) {
+	labelEnd := strings.Index(text, "](")
+	if labelEnd <= 1 {
+		return "", "", 0, false
+	}
+	urlEnd := strings.IndexByte(text[labelEnd+2:], ')')
+	if urlEnd <= 0 {
+		return "", "", 0, false
+	}
+	label = text[1:labelEnd]
+	url = strings.TrimSpace(text[labelEnd+2 : labelEnd+2+urlEnd])
+	return label, url, labelEnd + 2 + urlEnd + 1, true
+}
+
+// Returns the URL with an explicit scheme if it is considered safe,
+// otherwise, returns an empty string.
+//
+// URLs without scheme are assumed to use HTTPS (ex: "example.com" -> "https://example.com").
+func SanitizeURL(raw string) string {
+	if raw == "" || strings.ContainsAny(raw, " \t\n\"'<>`") {
+		return ""
+	}
+	scheme, _, hasScheme := strings.Cut(raw, ":")
+	if !hasScheme || strings.ContainsAny(scheme, "/.") {
+		return "https://" + strings.TrimPrefix(raw, "//")
+	}
+	switch strings.ToLower(scheme) {
+	case "http", "https", "mailto", "tel":
+		return raw
+	}
+	return ""
+}
@@ -0,0 +1,94 @@
+package mdlite
+
+import "testing"
+
+const linkAttrs = `target="_blank" rel="noopener noreferrer"`
+
+func TestToHTML(t *testing.T) {
+	tests := []struct {
+		src  string
+		want string
+	}{
+		// Escaping.
+		{`a < b > c & "d"`, `<p>a &lt; b &gt; c &amp; &#34;d&#34;</p>`},
+		{"<script>alert(1)</script>", `<p>&lt;script&gt;alert(1)&lt;/script&gt;</p>`},
+		{"`<b>`", `<p><code>&lt;b&gt;</code></p>`},
+
+		// Bold and emphasis.
+		{"**bold**", `<p><strong>bold</strong></p>`},
+		{"*emphasis* and _emphasis_", `<p><em>emphasis</em> and <em>emphasis</em></p>`},
+		{"**bold _and emphasis_**", `<p><strong>bold </strong><strong><em>and emphasis</em></strong></p>`},
+		{"snake_case_word and my_var", `<p>snake_case_word and my_var</p>`},
+		{"**unclosed and _unclosed", `<p>**unclosed and _unclosed</p>`},
+		{`\*escaped\*`, `<p>*escaped*</p>`},
+
+		// Links.
+		{"[site](https://example.com)", `<p><a ` + linkAttrs + ` href="https://example.com">site</a></p>`},
+		{"[site](example.com)", `<p><a ` + linkAttrs + ` href="https://example.com">site</a></p>`},
+		{"[**bold**](mailto:a@example.com)", `<p><a ` + linkAttrs + ` href="mailto:a@example.com"><strong>bold</strong></a></p>`},
+		{"[x](javascript:alert`1`)", `<p>x</p>`},
+		{"[x]( JavaScript:alert`1`)", `<p>x</p>`},
+		{"[not a link] (example.com)", `<p>[not a link] (example.com)</p>`},
+
+		// Paragraphs and lists.
+		{"first\nline\n\nsecond", `<p>first line</p><p>second</p>`},
+		{"- one\n* **two**\n\nafter", `<ul><li>one</li><li><strong>two</strong></li></ul><p>after</p>`},
+	}
+	for _, test := range tests {
+		if got := string(ToHTML(test.src)); got != test.want {
+			t.Errorf("%q:\nwant %s\ngot  %s", test.src, test.want, got)
+		}
+	}
+}
+
+func TestSanitizeURL(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want string
+	}{
+		{"https://example.com", "https://example.com"},
+		{"http://example.com", "http://example.com"},
+		{"HTTPS://example.com", "HTTPS://example.com"},
+		{"example.com/path", "https://example.com/path"},
+		{"//example.com", "https://example.com"},
+		{"mailto:a@example.com", "mailto:a@example.com"},
+		{"tel:+33612345678", "tel:+33612345678"},
+		{"", ""},
+		{"javascript:alert(1)", ""},
+		{"JavaScript:alert(1)", ""},
+		{" javascript:alert(1)", ""},
+		{"\tjavascript:alert(1)", ""},
+		{"data:text/html;base64,PHNjcmlwdD4=", ""},
+		{"DATA:text/html,x", ""},
+		{"vbscript:msgbox(1)", ""},
+		{"VBScript:msgbox(1)", ""},
+		{`https://example.com/"onmouseover="x`, ""},
+		{"https://example.com/<script>", ""},
+	}
+	for _, test := range tests {
+		if got := SanitizeURL(test.raw); got != test.want {
+			t.Errorf("%q: want %q, got %q", test.raw, test.want, got)
+		}
+	}
+}
+
+func TestToPlainText(t *testing.T) {
+	tests := []struct {
+		src  string
+		want string
+	}{
+		{`a < b & "c"`, `a < b & "c"`},
+		{"**bold**, *emphasis* and `code`", "bold, emphasis and code"},
+		{"snake_case_word", "snake_case_word"},
+		{"[site](https://example.com)", "site (https://example.com)"},
+		{"[example.com](example.com)", "example.com"},
+		{"[https://example.com](https://example.com)", "https://example.com"},
+		{"[x](javascript:alert`1`)", "x"},
+		{"intro\n\n- one\n- **two**\n\nend", "intro\n\n- one\n- two\n\nend"},
+	}
+	for _, test := range tests {
+		if got := ToPlainText(test.src); got != test.want {
+			t.Errorf("%q:\nwant %q\ngot  %q", test.src, test.want, got)
+		}
+	}
+}
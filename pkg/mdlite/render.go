@@ -0,0 +1,92 @@
+package mdlite
+
+import (
+	"html"
+	"html/template"
+	"strings"
+)
+
+// Renders the document as HTML.
+// All text is escaped, only a fixed set of tags is emitted,
+// and links are sanitized (see SanitizeURL).
+func (doc *Document) HTML() template.HTML {
+	b := &strings.Builder{}
+	for _, block := range doc.Blocks {
+		switch block.Kind {
+		case BlockParagraph:
+			b.WriteString("<p>")
+			writeSpansHTML(b, block.Items[0])
+			b.WriteString("</p>")
+		case BlockList:
+			b.WriteString("<ul>")
+			for _, item := range block.Items {
+				b.WriteString("<li>")
+				writeSpansHTML(b, item)
+				b.WriteString("</li>")
+			}
+			b.WriteString("</ul>")
+		}
+	}
+	return template.HTML(b.String())
+}
+
+func writeSpansHTML(b *strings.Builder, spans []Span) {
+	for _, span := range spans {
+		txt := html.EscapeString(span.Text)
+		if span.Code {
+			txt = "<code>" + txt + "</code>"
+		}
+		if span.Emphasis {
+			txt = "<em>" + txt + "</em>"
+		}
+		if span.Bold {
+			txt = "<strong>" + txt + "</strong>"
+		}
+		if span.URL != "" {
+			txt = `<a target="_blank" rel="noopener noreferrer" href="` + html.EscapeString(span.URL) + `">` + txt + "</a>"
+		}
+		b.WriteString(txt)
+	}
+}
+
+// Renders the document as plain text.
+// Paragraphs are separated by an empty line,
+// list items are written on their own line with a leading "- ",
+// and link URLs are written after their label (ex: "label (https://example.com)").
+func (doc *Document) PlainText() string {
+	b := &strings.Builder{}
+	for i, block := range doc.Blocks {
+		if i > 0 {
+			b.WriteString("\n\n")
+		}
+		switch block.Kind {
+		case BlockParagraph:
+			writeSpansPlainText(b, block.Items[0])
+		case BlockList:
+			for j, item := range block.Items {
+				if j > 0 {
+					b.WriteString("\n")
+				}
+				b.WriteString("- ")
+				writeSpansPlainText(b, item)
+			}
+		}
+	}
+	return b.String()
+}
+
+func writeSpansPlainText(b *strings.Builder, spans []Span) {
+	for i, span := range spans {
+		b.WriteString(span.Text)
+		isLastOfLink := span.URL != "" && (i+1 == len(spans) || spans[i+1].URL != span.URL)
+		if isLastOfLink && span.URL != span.Text && !strings.HasSuffix(span.URL, "://"+span.Text) {
+			b.WriteString(" (" + span.URL + ")")
+		}
+	}
+}
+
+// Parses the given text and renders it as HTML.
+func ToHTML(src string) template.HTML { return Parse(src).HTML() }
+
+// Parses the given text and renders it as plain text.
+func ToPlainText(src string) string { return Parse(src).PlainText() }
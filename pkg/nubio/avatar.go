@@ -0,0 +1,120 @@
+package nubio
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"os"
+)
+
+const (
+	maxAvatarFileSize  = 5_000_000 // In bytes.
+	minAvatarDimension = 32        // In pixels.
+	maxAvatarDimension = 8_000     // In pixels.
+)
+
+// Reads, validates and (optionally) downscales the avatar image at the given path.
+// When maxSize is positive, the image is re-encoded (which also strips metadata)
+// and downscaled if needed so that its width and height don't exceed maxSize.
+//
+// Returned image format is either "jpeg" or "png".
+func loadAvatar(path string, maxSize int) (b []byte, format string, err error) {
+	b, err = os.ReadFile(path)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(b) > maxAvatarFileSize {
+		return nil, "", fmt.Errorf("file is too big: %d bytes (max %d)", len(b), maxAvatarFileSize)
+	}
+
+	// Check format and dimensions.
+	imgConf, format, err := image.DecodeConfig(bytes.NewReader(b))
+	if err != nil {
+		return nil, "", fmt.Errorf("decode image config: %w", err)
+	}
+	if format != "jpeg" && format != "png" {
+		return nil, "", fmt.Errorf("unsupported image format: %q", format)
+	}
+	if imgConf.Width < minAvatarDimension || imgConf.Height < minAvatarDimension {
+		return nil, "", fmt.Errorf("image is too small: %dx%d (min %dpx)", imgConf.Width, imgConf.Height, minAvatarDimension)
+	}
+	if imgConf.Width > maxAvatarDimension || imgConf.Height > maxAvatarDimension {
+		return nil, "", fmt.Errorf("image is too big: %dx%d (max %dpx)", imgConf.Width, imgConf.Height, maxAvatarDimension)
+	}
+	if maxSize <= 0 {
+		return b, format, nil
+	}
+
+	// Downscale and re-encode.
+	img, _, err := image.Decode(bytes.NewReader(b))
+	if err != nil {
+		return nil, "", fmt.Errorf("decode image: %w", err)
+	}
+	img = downscale(img, maxSize)
+	buf := &bytes.Buffer{}
+	switch format {
+	case "jpeg":
+		err = jpeg.Encode(buf, img, &jpeg.Options{Quality: 90})
+	case "png":
+		err = png.Encode(buf, img)
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("encode image: %w", err)
+	}
+	return buf.Bytes(), format, nil
+}
+
+// Resizes the image (using a box filter) so that its width and height don't exceed maxSize,
+// the aspect ratio is preserved.
+func downscale(src image.Image, maxSize int) image.Image {
+	bounds := src.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+	if w <= maxSize && h <= maxSize {
+		return src
+	}
+	dw, dh := maxSize, maxSize
+	if w > h {
+		dh = max(1, h*maxSize/w)
+	} else {
+		dw = max(1, w*maxSize/h)
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+	for y := 0; y < dh; y++ {
+		sy0, sy1 := bounds.Min.Y+y*h/dh, bounds.Min.Y+(y+1)*h/dh
+		for x := 0; x < dw; x++ {
+			sx0, sx1 := bounds.Min.X+x*w/dw, bounds.Min.X+(x+1)*w/dw
+
+			// Average source pixels (premultiplied alpha).
+			var sumR, sumG, sumB, sumA, n uint64
+			for sy := sy0; sy < sy1; sy++ {
+				for sx := sx0; sx < sx1; sx++ {
+					r, g, b, a := src.At(sx, sy).RGBA()
+					sumR, sumG, sumB, sumA = sumR+uint64(r), sumG+uint64(g), sumB+uint64(b), sumA+uint64(a)
+					n++
+				}
+			}
+			dst.SetRGBA(x, y, color.RGBA{
+				R: uint8(sumR / n >> 8),
+				G: uint8(sumG / n >> 8),
+				B: uint8(sumB / n >> 8),
+				A: uint8(sumA / n >> 8),
+			})
+		}
+	}
+	return dst
+}
+
+// Returns the URL path of the avatar, or an empty string if no avatar is provided.
+func (conf *ResumeConfig) AvatarURLPath() string {
+	switch conf.AvatarFormat {
+	case "jpeg":
+		return PathAvatarJPEG
+	case "png":
+		return PathAvatarPNG
+	}
+	return ""
+}
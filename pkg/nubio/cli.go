@@ -1,54 +1,73 @@
 package nubio
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"fmt"
 	"log"
 	"os"
+	"path/filepath"
+	"strconv"
 
 	"github.com/ejuju/nubio/pkg/cli"
 )
 
 const version = "beta"
 
-func Run(args ...string) (exitcode int) {
-	if len(args) == 0 {
-		return commandRunServer.Do()
-	}
+var app = &cli.App{Name: "nubio"}
 
-	cmd := cli.Index(args[0], commands)
-	if cmd == nil {
-		fmt.Printf("Unknown command: %q.\n", args[0])
-		fmt.Printf("Use %q to list available commands.\n", commandHelp.Keyword)
+func Run(args ...string) (exitcode int) {
+	if _, _, err := SourceDateEpoch(); err != nil {
+		log.Print(err.Error())
 		return 1
 	}
-	return cmd.Do(args[1:]...)
+	if len(args) == 0 {
+		return app.Exec(commandRunServer.Keyword)
+	}
+	return app.Exec(args[0], args[1:]...)
 }
 
 var commands = []*cli.Command{
 	commandVersion,
+	commandInit,
 	commandRunServer,
 	commandRunSSG,
 	commandExport,
 	commandCheckResumeConfig,
 	commandCheckServerConfig,
+	commandCheckLinks,
+	commandMatch,
+	commandDiff,
+	commandFormat,
+	commandMigrate,
+	commandCompletion,
 }
 
 // Prepend help command.
-func init() { commands = append([]*cli.Command{commandHelp}, commands...) }
+func init() {
+	commands = append([]*cli.Command{commandHelp}, commands...)
+	app.Commands = commands
+}
 
 var commandHelp = &cli.Command{
 	Keyword:     "help",
-	Aliases:     []string{"--help", "?", "menu"},
-	Description: "Print available commands.",
-	Do: func(args ...string) (exitcode int) {
-		fmt.Printf("Available commands:\n")
-		for _, cmd := range commands {
-			fmt.Printf("- \x1b[33m%-14s\x1b[0m %s ", cmd.Keyword, cmd.Description)
-			if cmd.Usage != "" {
-				fmt.Printf("\x1b[30mExample: %q.\x1b[0m", cmd.Usage)
-			}
-			fmt.Print("\n")
+	Aliases:     []string{"--help", "-h", "?", "menu"},
+	Description: "Print available commands (or the usage of a given command).",
+	Args:        []*cli.Arg{{Name: "command", Description: "Command to describe.", Optional: true}},
+	Do: func(in *cli.Input) (exitcode int) {
+		keyword := in.Arg("command")
+		if keyword == "" {
+			cli.WriteCommandList(os.Stdout, app.Name, commands)
+			return 0
+		}
+		cmd := cli.Index(keyword, commands)
+		if cmd == nil {
+			fmt.Printf("Unknown command: %q.\n", keyword)
+			fmt.Printf("Use %q to list available commands.\n", app.Name+" help")
+			return 1
 		}
+		cli.WriteCommandHelp(os.Stdout, app.Name, cmd)
 		return 0
 	},
 }
@@ -57,45 +76,127 @@ var commandVersion = &cli.Command{
 	Keyword:     "version",
 	Aliases:     []string{"v", "-v", "--v", "-version", "--version"},
 	Description: "Print the version of this executable.",
-	Do: func(args ...string) (exitcode int) {
+	Do: func(in *cli.Input) (exitcode int) {
 		fmt.Printf("%s\n", version)
 		return 0
 	},
 }
 
+var commandInit = &cli.Command{
+	Keyword:     "init",
+	Description: "Create a new resume config file (and optionally a server config file).",
+	Flags: append([]*cli.Flag{
+		{Name: "out", Description: "Path of the resume config file.", Default: "resume.json", Path: true},
+		{Name: "server", Kind: cli.KindBool, Description: "Also create a server config file (server.json)."},
+		{Name: "server-out", Description: "Path of the server config file (implies --server).", Path: true},
+		{Name: "force", Kind: cli.KindBool, Description: "Overwrite existing files."},
+		{Name: "no-input", Kind: cli.KindBool, Description: "Use default values instead of asking missing values."},
+	}, initFieldFlags()...),
+	Do: func(in *cli.Input) (exitcode int) {
+		opts := InitOptions{
+			ResumePath: in.String("out"),
+			ServerPath: in.String("server-out"),
+			Force:      in.Bool("force"),
+			NoInput:    in.Bool("no-input"),
+			Values:     map[string]string{},
+		}
+		if opts.ServerPath == "" && in.Bool("server") {
+			opts.ServerPath = "server.json"
+		}
+		for _, field := range initFields {
+			if in.IsSet(field.Flag) {
+				opts.Values[field.Flag] = in.String(field.Flag)
+			}
+		}
+		return RunInit(opts)
+	},
+}
+
+// Note: Defaults are only shown in help, missing values are asked interactively.
+func initFieldFlags() (flags []*cli.Flag) {
+	for _, field := range initFields {
+		flags = append(flags, &cli.Flag{Name: field.Flag, Description: field.Prompt + ".", Default: field.Fallback})
+	}
+	return flags
+}
+
 var commandRunServer = &cli.Command{
 	Keyword:     "run",
-	Usage:       "run $PATH_TO_SERVER_CONF",
 	Description: "Run as HTTP(S) server.",
-	Do:          RunServer,
+	Args:        []*cli.Arg{{Name: "server_config_path", Description: "Path of the server config file.", Default: "server.json", Path: true}},
+	Do:          func(in *cli.Input) (exitcode int) { return RunServer(in.Arg("server_config_path")) },
 }
 
 var commandRunSSG = &cli.Command{
 	Keyword:     "ssg",
-	Usage:       "ssg $PATH_TO_CONFIG $PATH_TO_OUTPUT_DIR",
 	Description: "Generate static website files.",
-	Do:          RunSSG,
+	Args: []*cli.Arg{
+		{Name: "resume_config_path", Description: "Path of the resume config file.", Path: true},
+		{Name: "output_dir", Description: "Directory where files are written.", Path: true},
+	},
+	Do: func(in *cli.Input) (exitcode int) {
+		return RunSSG(in.Arg("resume_config_path"), in.Arg("output_dir"))
+	},
 }
 
+// Format used to export all formats at once.
+const exportAll = "all"
+
 var commandExport = &cli.Command{
 	Keyword:     "export",
-	Usage:       "export $FORMAT $RESUME_CONFIG_PATH $OUTPUT_PATH",
-	Description: "Export to file.",
-	Do: func(args ...string) (exitcode int) {
-		if len(args) < 3 {
-			log.Println("missing argument(s): format, resume_config_path, output_path")
-			return 1
+	Description: "Export to file (use \"-\" to read from stdin or write to stdout).",
+	Args: []*cli.Arg{
+		{Name: "format", Description: "Export format.", Choices: append(exportTypeNames(), exportAll)},
+		{Name: "resume_config_path", Description: "Path of the resume config file.", Path: true},
+		{Name: "output_path", Description: "Path of the output file (not used with \"all\").", Optional: true, Path: true},
+	},
+	Flags: []*cli.Flag{
+		{Name: "private", Kind: cli.KindBool, Description: "Include private fields."},
+		{Name: "out", Description: "Output directory (only used with \"all\").", Path: true},
+		{Name: "theme", Description: "PDF layout.", Choices: pdfThemes},
+		{Name: "page-size", Description: "PDF page size.", Choices: pdfPageSizes, IgnoreCase: true},
+		{Name: "margin", Kind: cli.KindFloat, Description: "PDF page margins (in points)."},
+		{Name: "font-size", Kind: cli.KindFloat, Description: "PDF base font size (in points)."},
+		{Name: "line-height", Kind: cli.KindFloat, Description: "PDF line height (relative to the font size)."},
+		{Name: "accent-color", Description: "PDF accent color (ex: \"#0a0a0a\")."},
+		{Name: "text-color", Description: "PDF text color."},
+		{Name: "muted-color", Description: "PDF muted text color."},
+	},
+	Do: func(in *cli.Input) (exitcode int) {
+		audience := AudiencePublic
+		if in.Bool("private") {
+			audience = AudiencePrivate
+		}
+
+		// List output files.
+		type output struct {
+			path     string
+			exporter *Exporter
+		}
+		outputs := []output{}
+		if format := in.Arg("format"); format == exportAll {
+			if in.String("out") == "" || in.Arg("output_path") != "" {
+				log.Printf("exporting all formats requires an output directory (--out) and no output path")
+				return 1
+			}
+			for _, exporter := range Exporters {
+				outputs = append(outputs, output{filepath.Join(in.String("out"), exporter.Filename), exporter})
+			}
+		} else {
+			if in.Arg("output_path") == "" || in.IsSet("out") {
+				log.Printf("exporting a single format requires an output path (and no --out)")
+				return 1
+			}
+			outputs = append(outputs, output{in.Arg("output_path"), GetExporter(ExportType(format))})
 		}
-		format := ExportType(args[0])
-		in := args[1]
-		out := args[2]
 
 		// Load and check resume config.
-		resumeConf, err := LoadResumeConfig(in)
+		resumeConf, err := LoadResumeConfig(in.Arg("resume_config_path"))
 		if err != nil {
 			log.Printf("load config: %s", err)
 			return 1
 		}
+		applyPDFFlags(in, &resumeConf.PDF)
 		errs := resumeConf.Check()
 		if len(errs) > 0 {
 			for _, err := range errs {
@@ -103,33 +204,44 @@ var commandExport = &cli.Command{
 			}
 			return 1
 		}
+		for _, warn := range resumeConf.Warnings() {
+			log.Printf("warning: %s", warn)
+		}
 
 		// Encode and write.
-		var exporter ExportFunc
-		switch format {
-		default:
-			log.Printf("unknown export format: %q", format)
-			return 1
-		case ExportTypeHTML:
-			exporter = ExportHTML
-		case ExportTypePDF:
-			exporter = ExportPDF
-		case ExportTypeJSON:
-			exporter = ExportJSON
-		}
-		f, err := os.OpenFile(out, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0666)
-		if err != nil {
-			log.Printf("open output file: %s", err)
-			return 1
+		if in.String("out") != "" {
+			err = os.MkdirAll(in.String("out"), 0777)
+			if err != nil {
+				log.Printf("create output directory: %s", err)
+				return 1
+			}
 		}
-		defer f.Close()
-		err = exporter(f, resumeConf)
-		if err != nil {
-			log.Printf("encode and write: %s", err)
-			return 1
+		exportOpts := DefaultExportOptions()
+		for _, v := range outputs {
+			out, exporter := v.path, v.exporter
+			if exporter.Type == ExportTypeHTML && len(resumeConf.Fonts.Regular) > 0 {
+				log.Printf("warning: custom fonts are loaded from /fonts/, they are only used when served with the HTML (by the server or the SSG)")
+			}
+			// Note: Exports are encoded before opening the output file, to avoid leaving a partial file on error.
+			b := &bytes.Buffer{}
+			err = exporter.Export(b, resumeConf.ForExport(exporter.Type, audience, exportOpts), exportOpts)
+			if err != nil {
+				log.Printf("encode %s: %s", exporter.Type, err)
+				return 1
+			}
+			if out == "-" {
+				_, err = os.Stdout.Write(b.Bytes())
+			} else {
+				err = os.WriteFile(out, b.Bytes(), 0666)
+			}
+			if err != nil {
+				log.Printf("write %s: %s", exporter.Type, err)
+				return 1
+			}
+			if out != "-" {
+				log.Printf("wrote export to %s", out)
+			}
 		}
-
-		log.Printf("wrote export to %s", out)
 		return 0
 	},
 }
@@ -137,20 +249,20 @@ var commandExport = &cli.Command{
 var commandCheckResumeConfig = &cli.Command{
 	Keyword:     "check-resume-config",
 	Aliases:     []string{"check-resume"},
-	Usage:       "check-resume-config $PATH_TO_CONFIG",
 	Description: "Check a resume config file.",
-	Do: func(args ...string) (exitcode int) {
+	Args:        []*cli.Arg{{Name: "resume_config_path", Description: "Path of the resume config file.", Default: "resume.json", Path: true}},
+	Do: func(in *cli.Input) (exitcode int) {
 		// Load config.
-		path := "resume.json"
-		if len(args) > 0 {
-			path = args[0]
-		}
+		path := in.Arg("resume_config_path")
 		log.Printf("Checking file: %s", path)
 		conf, err := LoadResumeConfig(path)
 		if err != nil {
 			log.Print(err.Error())
 			return 1
 		}
+		for _, warn := range conf.Warnings() {
+			log.Printf("- warning: %s", warn)
+		}
 		errs := conf.Check()
 		if len(errs) > 0 {
 			for _, err := range errs {
@@ -167,14 +279,11 @@ var commandCheckResumeConfig = &cli.Command{
 var commandCheckServerConfig = &cli.Command{
 	Keyword:     "check-server-config",
 	Aliases:     []string{"check-server"},
-	Usage:       "check-server-config $PATH_TO_CONFIG",
-	Description: "Check a resume config file.",
-	Do: func(args ...string) (exitcode int) {
+	Description: "Check a server config file.",
+	Args:        []*cli.Arg{{Name: "server_config_path", Description: "Path of the server config file.", Default: "server.json", Path: true}},
+	Do: func(in *cli.Input) (exitcode int) {
 		// Load config.
-		path := "server.json"
-		if len(args) > 0 {
-			path = args[0]
-		}
+		path := in.Arg("server_config_path")
 		log.Printf("Checking file: %s", path)
 		conf, err := LoadServerConfig(path)
 		if err != nil {
@@ -193,3 +302,347 @@ var commandCheckServerConfig = &cli.Command{
 		return 0
 	},
 }
+
+var commandCheckLinks = &cli.Command{
+	Keyword:     "check-links",
+	Description: "Check that all URLs of a resume config resolve.",
+	Args:        []*cli.Arg{{Name: "resume_config_path", Description: "Path of the resume config file.", Default: "resume.json", Path: true}},
+	Flags: []*cli.Flag{
+		{Name: "json", Kind: cli.KindBool, Description: "Print results as JSON."},
+		{Name: "timeout", Kind: cli.KindDuration, Description: "Timeout per link.", Default: "10s"},
+		{Name: "concurrency", Kind: cli.KindInt, Description: "Number of links checked in parallel.", Default: "8", Validate: checkPositive},
+	},
+	Do: func(in *cli.Input) (exitcode int) {
+		checker := &LinkChecker{Timeout: in.Duration("timeout"), Concurrency: in.Int("concurrency")}
+
+		// Load config.
+		conf, err := LoadResumeConfig(in.Arg("resume_config_path"))
+		if err != nil {
+			log.Print(err.Error())
+			return 1
+		}
+
+		// Check links and report results.
+		results := checker.Check(context.Background(), CollectLinkCheckTargets(conf))
+		if in.Bool("json") {
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "\t")
+			err = enc.Encode(results)
+		} else {
+			err = WriteLinkCheckTable(os.Stdout, results)
+		}
+		if err != nil {
+			log.Printf("write results: %s", err)
+			return 1
+		}
+		for _, v := range results {
+			if !v.OK {
+				return 1
+			}
+		}
+		return 0
+	},
+}
+
+var commandMatch = &cli.Command{
+	Keyword:     "match",
+	Description: "Report how well the resume matches a job posting (text file).",
+	Args: []*cli.Arg{
+		{Name: "resume_config_path", Description: "Path of the resume config file.", Path: true},
+		{Name: "job_posting_path", Description: "Path of the job posting (plain text).", Path: true},
+	},
+	Flags: []*cli.Flag{
+		{Name: "json", Kind: cli.KindBool, Description: "Print report as JSON."},
+		{Name: "synonyms", Description: "Path of a JSON file mapping keywords to synonyms.", Path: true},
+		{Name: "tailored", Description: "Write a resume config tailored to the job posting to the given path.", Path: true},
+	},
+	Do: func(in *cli.Input) (exitcode int) {
+		synonymsPath, tailoredPath := in.String("synonyms"), in.String("tailored")
+
+		// Load resume config, job posting and synonyms.
+		// Note: The file content is kept to write the tailored resume config (its schema is checked on load).
+		path := in.Arg("resume_config_path")
+		b, err := readFileOrStdin(path)
+		if err != nil {
+			log.Printf("read config file: %s", err)
+			return 1
+		}
+		conf, err := loadResumeConfig(b, path)
+		if err != nil {
+			log.Print(err.Error())
+			return 1
+		}
+		posting, err := os.ReadFile(in.Arg("job_posting_path"))
+		if err != nil {
+			log.Printf("read job posting: %s", err)
+			return 1
+		}
+		opts := MatchOptions{}
+		if synonymsPath != "" {
+			b, err := os.ReadFile(synonymsPath)
+			if err != nil {
+				log.Printf("read synonyms: %s", err)
+				return 1
+			}
+			err = json.Unmarshal(b, &opts.Synonyms)
+			if err != nil {
+				log.Printf("decode synonyms: %s", err)
+				return 1
+			}
+		}
+
+		// Match and report.
+		report := MatchJobPosting(conf, string(posting), opts)
+		if in.Bool("json") {
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "\t")
+			err = enc.Encode(report)
+		} else {
+			err = WriteMatchReport(os.Stdout, report)
+		}
+		if err != nil {
+			log.Printf("write report: %s", err)
+			return 1
+		}
+
+		// Write tailored resume config if needed.
+		if tailoredPath != "" {
+			tailored, err := TailorResumeConfig(b, report)
+			if err != nil {
+				log.Printf("tailor resume config: %s", err)
+				return 1
+			}
+			err = os.WriteFile(tailoredPath, tailored, 0666)
+			if err != nil {
+				log.Printf("write tailored resume config: %s", err)
+				return 1
+			}
+			log.Printf("wrote tailored resume config to %s", tailoredPath)
+		}
+		return 0
+	},
+}
+
+var commandDiff = &cli.Command{
+	Keyword:     "diff",
+	Description: "Print changes between two versions of a resume config.",
+	Args: []*cli.Arg{
+		{Name: "old_resume_config_path", Description: "Path of the old resume config file.", Path: true},
+		{Name: "new_resume_config_path", Description: "Path of the new resume config file.", Path: true},
+	},
+	Flags: []*cli.Flag{{Name: "json", Kind: cli.KindBool, Description: "Print changes as JSON."}},
+	Do: func(in *cli.Input) (exitcode int) {
+		// Decode both configs.
+		// Note: Configs are not loaded using LoadResumeConfig,
+		// referenced files (ex: avatar) may not exist for older versions.
+		confs := [2]*ResumeConfig{}
+		for i, path := range []string{in.Arg("old_resume_config_path"), in.Arg("new_resume_config_path")} {
+			b, err := os.ReadFile(path)
+			if err != nil {
+				log.Printf("read config file: %s", err)
+				return 1
+			}
+			err = checkSchema(b, path)
+			if err != nil {
+				log.Print(err.Error())
+				return 1
+			}
+			confs[i] = &ResumeConfig{}
+			err = json.Unmarshal(b, confs[i])
+			if err != nil {
+				log.Printf("decode config file %s: %s", path, err)
+				return 1
+			}
+			normalizeResumeURLs(confs[i])
+		}
+
+		// Diff and report.
+		changes := DiffResumes(confs[0], confs[1])
+		var err error
+		if in.Bool("json") {
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "\t")
+			err = enc.Encode(changes)
+		} else {
+			err = WriteResumeDiff(os.Stdout, changes)
+		}
+		if err != nil {
+			log.Printf("write changes: %s", err)
+			return 1
+		}
+		return 0
+	},
+}
+
+var commandFormat = &cli.Command{
+	Keyword:     "fmt",
+	Description: "Format a resume config file.",
+	Args:        []*cli.Arg{{Name: "resume_config_path", Description: "Path of the resume config file.", Default: "resume.json", Path: true}},
+	Flags: []*cli.Flag{
+		{Name: "check", Kind: cli.KindBool, Description: "Only report unformatted files (exit code 1)."},
+		{Name: "sort", Kind: cli.KindBool, Description: "Sort entries by date (most recent first)."},
+	},
+	Do: func(in *cli.Input) (exitcode int) {
+		path := in.Arg("resume_config_path")
+		opts := FormatOptions{SortByDate: in.Bool("sort")}
+
+		// Format.
+		b, err := os.ReadFile(path)
+		if err != nil {
+			log.Printf("read config file: %s", err)
+			return 1
+		}
+		err = checkSchema(b, path)
+		if err != nil {
+			log.Print(err.Error())
+			return 1
+		}
+		formatted, err := FormatResumeConfig(b, opts)
+		if err != nil {
+			log.Print(err.Error())
+			return 1
+		}
+		if bytes.Equal(b, formatted) {
+			return 0
+		}
+		if in.Bool("check") {
+			log.Printf("%s is not formatted, run: nubio fmt %s", path, path)
+			return 1
+		}
+
+		// Write formatted file.
+		err = os.WriteFile(path, formatted, 0666)
+		if err != nil {
+			log.Printf("write config file: %s", err)
+			return 1
+		}
+		log.Printf("formatted %s", path)
+		return 0
+	},
+}
+
+var commandMigrate = &cli.Command{
+	Keyword:     "migrate",
+	Description: "Rewrite an outdated resume config (or legacy config.json) to the current layout.",
+	Args:        []*cli.Arg{{Name: "config_path", Description: "Path of the resume config (or legacy config.json) file.", Default: "resume.json", Path: true}},
+	Flags: []*cli.Flag{
+		{Name: "out", Description: "Path of the migrated resume config (defaults to the input file).", Path: true},
+		{Name: "server-out", Description: "Path of the server config extracted from a legacy config.json (defaults to server.json).", Path: true},
+		{Name: "force", Kind: cli.KindBool, Description: "Overwrite existing files."},
+		{Name: "dry-run", Kind: cli.KindBool, Description: "Only print changes."},
+	},
+	Do: func(in *cli.Input) (exitcode int) {
+		path := in.Arg("config_path")
+		resumePath, serverPath := in.String("out"), in.String("server-out")
+
+		// Migrate.
+		b, err := os.ReadFile(path)
+		if err != nil {
+			log.Printf("read config file: %s", err)
+			return 1
+		}
+		if resumePath == "" {
+			resumePath = path
+			if filepath.Base(path) == "config.json" {
+				resumePath = filepath.Join(filepath.Dir(path), "resume.json")
+			}
+		}
+		result, err := MigrateConfig(b, resumePath)
+		if err != nil {
+			log.Print(err.Error())
+			return 1
+		}
+		if serverPath == "" {
+			serverPath = filepath.Join(filepath.Dir(path), "server.json")
+		}
+		if len(result.Changes) == 0 {
+			log.Printf("%s is up to date", path)
+			return 0
+		}
+		for _, change := range result.Changes {
+			log.Printf("- %s", change)
+		}
+		for _, key := range result.Dropped {
+			log.Printf("- warning: field dropped: %s", key)
+		}
+		if in.Bool("dry-run") {
+			return 0
+		}
+
+		// Write files (refuse to overwrite files other than the migrated one).
+		files := map[string][]byte{resumePath: result.Resume}
+		if result.Server != nil {
+			files[serverPath] = result.Server
+		}
+		for outPath := range files {
+			if _, err := os.Stat(outPath); err == nil && outPath != path && !in.Bool("force") {
+				log.Printf("%s already exists (use --force to overwrite it)", outPath)
+				return 1
+			}
+		}
+		for outPath, b := range files {
+			err = os.WriteFile(outPath, b, 0666)
+			if err != nil {
+				log.Printf("write config file: %s", err)
+				return 1
+			}
+			log.Printf("wrote %s", outPath)
+		}
+		return 0
+	},
+}
+
+var commandCompletion = &cli.Command{
+	Keyword:     "completion",
+	Description: "Print a shell completion script.",
+	Args:        []*cli.Arg{{Name: "shell", Choices: cli.Shells}},
+	Do: func(in *cli.Input) (exitcode int) {
+		err := cli.WriteCompletion(os.Stdout, app, in.Arg("shell"))
+		if err != nil {
+			log.Print(err.Error())
+			return 1
+		}
+		return 0
+	},
+}
+
+// Overrides the PDF options of the resume config with the provided flags.
+func applyPDFFlags(in *cli.Input, opts *PDFOptions) {
+	for flag, v := range map[string]*string{
+		"theme":        &opts.Theme,
+		"page-size":    &opts.PageSize,
+		"accent-color": &opts.AccentColor,
+		"text-color":   &opts.TextColor,
+		"muted-color":  &opts.MutedColor,
+	} {
+		if in.IsSet(flag) {
+			*v = in.String(flag)
+		}
+	}
+	for flag, v := range map[string]*float64{
+		"font-size":   &opts.FontSize,
+		"line-height": &opts.LineHeight,
+	} {
+		if in.IsSet(flag) {
+			*v = in.Float(flag)
+		}
+	}
+	if in.IsSet("margin") {
+		margin := in.Float("margin") // Note: Zero is a valid margin.
+		opts.Margin = &margin
+	}
+}
+
+func exportTypeNames() (names []string) {
+	for _, exporter := range Exporters {
+		names = append(names, string(exporter.Type))
+	}
+	return names
+}
+
+func checkPositive(v string) error {
+	if n, _ := strconv.Atoi(v); n <= 0 {
+		return fmt.Errorf("must be positive")
+	}
+	return nil
+}
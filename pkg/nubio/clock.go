@@ -0,0 +1,32 @@
+package nubio
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+)
+
+// Returns the export options set by the environment:
+// the SOURCE_DATE_EPOCH time is used (if set) to produce reproducible exports.
+// Note: Invalid SOURCE_DATE_EPOCH values are ignored here, they are reported by the CLI.
+func DefaultExportOptions() (opts ExportOptions) {
+	if t, ok, err := SourceDateEpoch(); ok && err == nil {
+		opts.Time = t
+	}
+	return opts
+}
+
+// Returns the time set by the SOURCE_DATE_EPOCH environment variable (Unix timestamp),
+// see https://reproducible-builds.org/specs/source-date-epoch/.
+func SourceDateEpoch() (t time.Time, ok bool, err error) {
+	raw, ok := os.LookupEnv("SOURCE_DATE_EPOCH")
+	if !ok || raw == "" {
+		return t, false, nil
+	}
+	sec, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil || sec < 0 {
+		return t, true, fmt.Errorf("invalid SOURCE_DATE_EPOCH: %q", raw)
+	}
+	return time.Unix(sec, 0).UTC(), true, nil
+}
@@ -0,0 +1,51 @@
+package nubio
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestReproducibleExports(t *testing.T) {
+	t.Setenv("SOURCE_DATE_EPOCH", "1700000000") // 2023-11-14 22:13:20 UTC.
+
+	// Note: The fixture has "now" dates (current work experience),
+	// SOURCE_DATE_EPOCH must not affect their resolution when checking the config.
+	conf, err := LoadResumeConfig("../../resume.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	conf.HideExpiredCertifications = true
+	conf.Certifications = []Certification{
+		{Title: "Valid at epoch", Issuer: "Example", Date: "January 2022", Expiry: "December 2023"},
+		{Title: "Expired at epoch", Issuer: "Example", Date: "January 2020", Expiry: "January 2021"},
+	}
+	for _, err := range conf.Check() {
+		t.Errorf("check config: %s", err)
+	}
+
+	for _, exporter := range Exporters {
+		exports := [2][]byte{}
+		for i := range exports {
+			b := &bytes.Buffer{}
+			opts := DefaultExportOptions()
+			err := exporter.Export(b, conf.ForExport(exporter.Type, AudiencePublic, opts), opts)
+			if err != nil {
+				t.Fatalf("%s: %s", exporter.Type, err)
+			}
+			exports[i] = b.Bytes()
+		}
+		if !bytes.Equal(exports[0], exports[1]) {
+			t.Errorf("%s: exports are not identical", exporter.Type)
+		}
+		isRendered := exporter.Type == ExportTypeHTML || exporter.Type == ExportTypeJSON // Note: The PDF is compressed.
+		if isRendered && !bytes.Contains(exports[0], []byte("Valid at epoch")) {
+			t.Errorf("%s: certification expired after SOURCE_DATE_EPOCH was hidden", exporter.Type)
+		}
+		if isRendered && bytes.Contains(exports[0], []byte("Expired at epoch")) {
+			t.Errorf("%s: certification expired before SOURCE_DATE_EPOCH was not hidden", exporter.Type)
+		}
+		if exporter.Type == ExportTypePDF && !bytes.Contains(exports[0], []byte("D:20231114221320")) {
+			t.Errorf("%s: missing creation date from SOURCE_DATE_EPOCH", exporter.Type)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package nubio
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+)
+
+// Holds optional contact information, in addition to the email address.
+type ContactDetails struct {
+	Phones          []PhoneNumber   `json:"phones"`
+	Location        ContactLocation `json:"location"`
+	TimeZone        ContactField    `json:"time_zone"`        // IANA time zone name (ex: "Europe/Paris").
+	Availability    ContactField    `json:"availability"`     // Ex: "Open to new opportunities".
+	PreferredMethod ContactField    `json:"preferred_method"` // One of: "email", "phone".
+}
+
+type PhoneNumber struct {
+	Label      string     `json:"label"`  // Optional (ex: "Mobile").
+	Number     string     `json:"number"` // In E.164 format (ex: "+33612345678").
+	Visibility Visibility `json:"visibility,omitempty"`
+}
+
+type ContactLocation struct {
+	City       string     `json:"city"`
+	Country    string     `json:"country"`
+	Visibility Visibility `json:"visibility,omitempty"`
+}
+
+// Returns "City, Country" (or only one of both if the other is missing).
+func (v ContactLocation) String() string {
+	switch {
+	case v.City == "":
+		return v.Country
+	case v.Country == "":
+		return v.City
+	}
+	return v.City + ", " + v.Country
+}
+
+type ContactField struct {
+	Value      string     `json:"value"`
+	Visibility Visibility `json:"visibility,omitempty"`
+}
+
+const (
+	ContactMethodEmail = "email"
+	ContactMethodPhone = "phone"
+)
+
+var e164Regexp = regexp.MustCompile(`^\+[1-9][0-9]{1,14}$`)
+
+// Note: All fields are optional.
+func (v *ContactDetails) Check() (errs []error) {
+	for i, phone := range v.Phones {
+		if phone.Number == "" {
+			errs = append(errs, fmt.Errorf("phone %d: missing number", i))
+		} else if !e164Regexp.MatchString(phone.Number) {
+			errs = append(errs, fmt.Errorf("phone %d: number is not in E.164 format: %q", i, phone.Number))
+		}
+		if err := phone.Visibility.Check(); err != nil {
+			errs = append(errs, fmt.Errorf("phone %d: %w", i, err))
+		}
+	}
+
+	if err := v.Location.Visibility.Check(); err != nil {
+		errs = append(errs, fmt.Errorf("location: %w", err))
+	}
+
+	if v.TimeZone.Value != "" {
+		if _, err := time.LoadLocation(v.TimeZone.Value); err != nil {
+			errs = append(errs, fmt.Errorf("invalid time zone: %w", err))
+		}
+	}
+	if err := v.TimeZone.Visibility.Check(); err != nil {
+		errs = append(errs, fmt.Errorf("time zone: %w", err))
+	}
+
+	if err := v.Availability.Visibility.Check(); err != nil {
+		errs = append(errs, fmt.Errorf("availability: %w", err))
+	}
+
+	switch v.PreferredMethod.Value {
+	case "", ContactMethodEmail:
+	case ContactMethodPhone:
+		if len(v.Phones) == 0 {
+			errs = append(errs, errors.New("preferred contact method is phone but no phone number is provided"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("unknown preferred contact method: %q", v.PreferredMethod.Value))
+	}
+	if err := v.PreferredMethod.Visibility.Check(); err != nil {
+		errs = append(errs, fmt.Errorf("preferred contact method: %w", err))
+	}
+
+	return errs
+}
+
+// Returns a copy without the fields that are not rendered for the given export type and audience.
+func (v ContactDetails) visibleIn(typ ExportType, audience Audience) ContactDetails {
+	v.Phones = filterVisible(v.Phones, typ, audience, func(v PhoneNumber) Visibility { return v.Visibility })
+	if !v.Location.Visibility.IsVisibleIn(typ, audience) {
+		v.Location = ContactLocation{}
+	}
+	for _, field := range []*ContactField{&v.TimeZone, &v.Availability, &v.PreferredMethod} {
+		if !field.Visibility.IsVisibleIn(typ, audience) {
+			*field = ContactField{}
+		}
+	}
+	return v
+}
+
+// Returns a copy with an empty visibility on all fields (used for exports).
+func (v ContactDetails) withoutVisibility() ContactDetails {
+	v.Phones = withoutVisibility(v.Phones, func(v *PhoneNumber) *Visibility { return &v.Visibility })
+	v.Location.Visibility = ""
+	for _, field := range []*ContactField{&v.TimeZone, &v.Availability, &v.PreferredMethod} {
+		field.Visibility = ""
+	}
+	return v
+}
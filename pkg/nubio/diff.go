@@ -0,0 +1,252 @@
+package nubio
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// Kinds of resume changes.
+const (
+	ChangeAdded   = "added"
+	ChangeRemoved = "removed"
+	ChangeChanged = "changed"
+)
+
+// A semantic change between two versions of a resume config.
+type ResumeChange struct {
+	Kind    string `json:"kind"`            // One of: "added", "removed", "changed".
+	Section string `json:"section"`         // JSON name of the section (ex: "work_experience").
+	Entry   string `json:"entry,omitempty"` // Entry label (ex: "Backend Engineer at Acme (May 2021)"), empty for top-level fields.
+	Field   string `json:"field,omitempty"` // JSON name of the changed field (only for "changed").
+	Old     any    `json:"old,omitempty"`
+	New     any    `json:"new,omitempty"`
+}
+
+// Compares two resume configs.
+// Entries are matched by identifying fields (ex: title, organization and start date) rather than by index,
+// so that reordered entries are not reported as changes.
+func DiffResumes(old, new *ResumeConfig) (changes []ResumeChange) {
+	// Diff top-level fields (sections are handled below).
+	sections := []string{
+		"links", "work_experience", "projects", "skills", "languages", "education",
+		"certifications", "publications", "talks", "awards", "interests", "hobbies", "custom_sections",
+	}
+	changes = append(changes, diffFields("", "", *old, *new, sections...)...)
+
+	workExperienceKeys := []func(v WorkExperience) string{
+		func(v WorkExperience) string { return v.Title + "\x00" + v.Organization + "\x00" + v.From },
+		func(v WorkExperience) string { return v.Title + "\x00" + v.Organization },
+		func(v WorkExperience) string { return v.Organization + "\x00" + v.From },
+	}
+	educationKeys := []func(v Education) string{
+		func(v Education) string { return v.Title + "\x00" + v.Organization + "\x00" + v.From },
+		func(v Education) string { return v.Title + "\x00" + v.Organization },
+		func(v Education) string { return v.Organization + "\x00" + v.From },
+	}
+
+	changes = append(changes, diffEntries("links", old.Links, new.Links,
+		func(v Link) string { return v.Label }, nil,
+		func(v Link) string { return v.Label },
+		func(v Link) string { return v.URL },
+	)...)
+	changes = append(changes, diffEntries("work_experience", old.WorkExperience, new.WorkExperience,
+		func(v WorkExperience) string { return entryLabel(v.Title, v.Organization, v.From) }, nil,
+		workExperienceKeys...,
+	)...)
+	changes = append(changes, diffEntries("projects", old.Projects, new.Projects,
+		func(v Project) string { return v.Name }, nil,
+		func(v Project) string { return v.Name },
+		func(v Project) string { return v.Repository },
+		func(v Project) string { return v.URL },
+	)...)
+	changes = append(changes, diffEntries("skills", old.Skills, new.Skills,
+		func(v Skill) string { return v.Title }, nil,
+		func(v Skill) string { return v.Title },
+	)...)
+	changes = append(changes, diffEntries("languages", old.Languages, new.Languages,
+		func(v Language) string { return v.Label }, nil,
+		func(v Language) string { return v.Label },
+	)...)
+	changes = append(changes, diffEntries("education", old.Education, new.Education,
+		func(v Education) string { return entryLabel(v.Title, v.Organization, v.From) }, nil,
+		educationKeys...,
+	)...)
+	changes = append(changes, diffEntries("certifications", old.Certifications, new.Certifications,
+		func(v Certification) string { return entryLabel(v.Title, v.Issuer, v.Date) }, nil,
+		func(v Certification) string { return v.Title + "\x00" + v.Issuer + "\x00" + v.Date },
+		func(v Certification) string { return v.Title + "\x00" + v.Issuer },
+	)...)
+	changes = append(changes, diffEntries("publications", old.Publications, new.Publications,
+		func(v Publication) string { return entryLabel(v.Title, v.Publisher, v.Date) }, nil,
+		func(v Publication) string { return v.Title },
+		func(v Publication) string { return v.URL },
+	)...)
+	changes = append(changes, diffEntries("talks", old.Talks, new.Talks,
+		func(v Talk) string { return entryLabel(v.Title, v.Event, v.Date) }, nil,
+		func(v Talk) string { return v.Title + "\x00" + v.Event },
+		func(v Talk) string { return v.Title },
+	)...)
+	changes = append(changes, diffEntries("awards", old.Awards, new.Awards,
+		func(v Award) string { return entryLabel(v.Title, v.Issuer, v.Date) }, nil,
+		func(v Award) string { return v.Title + "\x00" + v.Issuer },
+		func(v Award) string { return v.Title },
+	)...)
+	changes = append(changes, diffStrings("interests", old.Interests, new.Interests)...)
+	changes = append(changes, diffStrings("hobbies", old.Hobbies, new.Hobbies)...)
+
+	// Diff custom sections (matched by heading), and their entries.
+	heading := func(v CustomSection) string { return v.Heading }
+	// Note: Entries are diffed separately (below).
+	changes = append(changes, diffEntries("custom_sections", old.CustomSections, new.CustomSections, heading, []string{"entries"}, heading)...)
+	for _, newSection := range new.CustomSections {
+		i := slices.IndexFunc(old.CustomSections, func(v CustomSection) bool { return v.Heading == newSection.Heading })
+		if i < 0 {
+			continue
+		}
+		changes = append(changes, diffEntries("custom_sections."+newSection.Heading, old.CustomSections[i].Entries, newSection.Entries,
+			func(v CustomSectionEntry) string { return entryLabel(v.Title, v.Subtitle, v.From) }, nil,
+			func(v CustomSectionEntry) string { return v.Title + "\x00" + v.Subtitle + "\x00" + v.From },
+			func(v CustomSectionEntry) string { return v.Title + "\x00" + v.Subtitle },
+			func(v CustomSectionEntry) string { return v.Title },
+		)...)
+	}
+	return changes
+}
+
+// Returns "title at organization (date)", omitting empty parts.
+func entryLabel(title, organization, date string) string {
+	label := title
+	if organization != "" {
+		label += " at " + organization
+	}
+	if date != "" {
+		label += " (" + date + ")"
+	}
+	return label
+}
+
+// Matches old and new entries using the given keys (from the most to the least specific),
+// and reports added, removed and changed entries (fields in skip are not compared).
+// Note: Entries with an empty key are not matched using this key.
+func diffEntries[T any](section string, old, new []T, label func(T) string, skip []string, keys ...func(T) string) (changes []ResumeChange) {
+	oldMatches := make([]int, len(new)) // Index of the matching old entry for each new entry (or -1).
+	for i := range oldMatches {
+		oldMatches[i] = -1
+	}
+	isOldMatched := make([]bool, len(old))
+	for _, key := range keys {
+		for i, newEntry := range new {
+			newKey := key(newEntry)
+			if oldMatches[i] >= 0 || strings.Trim(newKey, "\x00") == "" {
+				continue
+			}
+			for j, oldEntry := range old {
+				if !isOldMatched[j] && key(oldEntry) == newKey {
+					oldMatches[i], isOldMatched[j] = j, true
+					break
+				}
+			}
+		}
+	}
+
+	for i, newEntry := range new {
+		if oldMatches[i] < 0 {
+			changes = append(changes, ResumeChange{Kind: ChangeAdded, Section: section, Entry: label(newEntry), New: newEntry})
+			continue
+		}
+		changes = append(changes, diffFields(section, label(newEntry), old[oldMatches[i]], newEntry, skip...)...)
+	}
+	for j, oldEntry := range old {
+		if !isOldMatched[j] {
+			changes = append(changes, ResumeChange{Kind: ChangeRemoved, Section: section, Entry: label(oldEntry), Old: oldEntry})
+		}
+	}
+	return changes
+}
+
+// Reports changed fields (by JSON name) between two structs of the same type.
+// When section is empty, the fields are reported as top-level sections.
+func diffFields(section, entry string, old, new any, skip ...string) (changes []ResumeChange) {
+	oldValue, newValue := reflect.ValueOf(old), reflect.ValueOf(new)
+	for i := 0; i < oldValue.NumField(); i++ {
+		name, _, _ := strings.Cut(oldValue.Type().Field(i).Tag.Get("json"), ",")
+		if name == "" || name == "-" || slices.Contains(skip, name) {
+			continue
+		}
+		oldField, newField := oldValue.Field(i).Interface(), newValue.Field(i).Interface()
+		if isEmptyDiffValue(oldField) && isEmptyDiffValue(newField) {
+			continue // Note: Nil and empty slices are considered equal.
+		}
+		if reflect.DeepEqual(oldField, newField) {
+			continue
+		}
+		change := ResumeChange{Kind: ChangeChanged, Section: section, Entry: entry, Field: name, Old: oldField, New: newField}
+		if section == "" {
+			change.Section, change.Field = name, "" // Top-level field.
+		}
+		changes = append(changes, change)
+	}
+	return changes
+}
+
+func isEmptyDiffValue(v any) bool {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Map:
+		return rv.Len() == 0
+	}
+	return rv.IsZero()
+}
+
+// Reports added and removed strings.
+func diffStrings(section string, old, new []string) (changes []ResumeChange) {
+	for _, v := range new {
+		if !slices.Contains(old, v) {
+			changes = append(changes, ResumeChange{Kind: ChangeAdded, Section: section, Entry: v})
+		}
+	}
+	for _, v := range old {
+		if !slices.Contains(new, v) {
+			changes = append(changes, ResumeChange{Kind: ChangeRemoved, Section: section, Entry: v})
+		}
+	}
+	return changes
+}
+
+// Writes the changes in a human-readable format.
+func WriteResumeDiff(w io.Writer, changes []ResumeChange) error {
+	if len(changes) == 0 {
+		_, err := io.WriteString(w, "No changes.\n")
+		return err
+	}
+	b := &strings.Builder{}
+	for _, v := range changes {
+		prefix := map[string]string{ChangeAdded: "+", ChangeRemoved: "-", ChangeChanged: "~"}[v.Kind]
+		path := v.Section
+		if v.Entry != "" {
+			path += ": " + v.Entry
+		}
+		switch {
+		case v.Kind == ChangeChanged && v.Field != "":
+			fmt.Fprintf(b, "%s %s: %s: %s -> %s\n", prefix, path, v.Field, formatDiffValue(v.Old), formatDiffValue(v.New))
+		case v.Kind == ChangeChanged:
+			fmt.Fprintf(b, "%s %s: %s -> %s\n", prefix, path, formatDiffValue(v.Old), formatDiffValue(v.New))
+		default:
+			fmt.Fprintf(b, "%s %s\n", prefix, path)
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func formatDiffValue(v any) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
+}
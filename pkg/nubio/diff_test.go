@@ -0,0 +1,92 @@
+package nubio
+
+import (
+	"bytes"
+	"slices"
+	"testing"
+)
+
+func TestDiffResumes(t *testing.T) {
+	old := &ResumeConfig{
+		Name: "Jane Doe",
+		WorkExperience: []WorkExperience{
+			{Title: "Backend Engineer", Organization: "Acme", From: "May 2021", To: "now"},
+			{Title: "Intern", Organization: "Initech", From: "January 2020", To: "April 2021"},
+		},
+		CustomSections: []CustomSection{{
+			Heading: "Volunteering",
+			Entries: []CustomSectionEntry{{Title: "Mentor"}, {Title: "Organizer"}},
+		}},
+	}
+
+	tests := []struct {
+		name   string
+		update func(conf *ResumeConfig)
+		want   string
+	}{
+		{
+			name:   "no changes",
+			update: func(conf *ResumeConfig) {},
+			want:   "No changes.\n",
+		},
+		{
+			name: "reordered entries",
+			update: func(conf *ResumeConfig) {
+				conf.WorkExperience = []WorkExperience{conf.WorkExperience[1], conf.WorkExperience[0]}
+				conf.CustomSections[0].Entries = []CustomSectionEntry{{Title: "Organizer"}, {Title: "Mentor"}}
+			},
+			want: "No changes.\n",
+		},
+		{
+			name:   "changed field",
+			update: func(conf *ResumeConfig) { conf.WorkExperience[0].To = "June 2024" },
+			want:   "~ work_experience: Backend Engineer at Acme (May 2021): to: \"now\" -> \"June 2024\"\n",
+		},
+		{
+			name: "changed custom entry",
+			update: func(conf *ResumeConfig) {
+				conf.CustomSections[0].Entries = []CustomSectionEntry{{Title: "Mentor", Body: "Weekly sessions."}, {Title: "Organizer"}}
+			},
+			want: "~ custom_sections.Volunteering: Mentor: body: \"\" -> \"Weekly sessions.\"\n",
+		},
+		{
+			name: "added and removed custom entries",
+			update: func(conf *ResumeConfig) {
+				conf.CustomSections[0].Entries = []CustomSectionEntry{{Title: "Mentor"}, {Title: "Speaker"}}
+			},
+			want: "+ custom_sections.Volunteering: Speaker\n- custom_sections.Volunteering: Organizer\n",
+		},
+		{
+			name: "added custom section",
+			update: func(conf *ResumeConfig) {
+				conf.CustomSections = append(conf.CustomSections, CustomSection{Heading: "Press", Entries: []CustomSectionEntry{{Title: "Interview"}}})
+			},
+			want: "+ custom_sections: Press\n",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			new := cloneResumeConfig(old)
+			test.update(new)
+			b := &bytes.Buffer{}
+			err := WriteResumeDiff(b, DiffResumes(old, new))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if b.String() != test.want {
+				t.Errorf("want:\n%s\ngot:\n%s", test.want, b)
+			}
+		})
+	}
+}
+
+// Returns a copy of the resume config (with copies of the slices modified by the tests).
+func cloneResumeConfig(conf *ResumeConfig) *ResumeConfig {
+	out := *conf
+	out.WorkExperience = slices.Clone(conf.WorkExperience)
+	out.CustomSections = slices.Clone(conf.CustomSections)
+	for i := range out.CustomSections {
+		out.CustomSections[i].Entries = slices.Clone(conf.CustomSections[i].Entries)
+	}
+	return &out
+}
@@ -0,0 +1,108 @@
+package nubio
+
+import (
+	"encoding/base64"
+	"fmt"
+	"html/template"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Email obfuscation modes (used to protect the email address from scrapers on the HTML page).
+const (
+	EmailObfuscationNone     = ""         // Email address is written in clear text.
+	EmailObfuscationEntities = "entities" // Email address is written using HTML character references.
+	EmailObfuscationJS       = "js"       // Email address is encoded and revealed using JavaScript.
+	EmailObfuscationRedirect = "redirect" // Email address is revealed on "/contact" after a confirmation (server only).
+)
+
+func checkEmailObfuscation(mode string) error {
+	switch mode {
+	case EmailObfuscationNone, EmailObfuscationEntities, EmailObfuscationJS, EmailObfuscationRedirect:
+		return nil
+	}
+	return fmt.Errorf("unknown email obfuscation mode: %q", mode)
+}
+
+// Encodes every character of the given string as a decimal HTML character reference.
+func encodeHTMLEntities(v string) string {
+	b := &strings.Builder{}
+	for _, c := range v {
+		fmt.Fprintf(b, "&#%d;", c)
+	}
+	return b.String()
+}
+
+// Returns the email address reversed and base64 encoded (decoded on the client).
+func encodeEmailForJS(v string) string {
+	runes := []rune(v)
+	for i, j := 0, len(runes)-1; i < j; i, j = i+1, j-1 {
+		runes[i], runes[j] = runes[j], runes[i]
+	}
+	return base64.StdEncoding.EncodeToString([]byte(string(runes)))
+}
+
+// Returns the email button as HTML (according to the obfuscation mode).
+func (conf *ResumeConfig) EmailButtonHTML() template.HTML {
+	switch conf.EmailObfuscation {
+	case EmailObfuscationEntities:
+		return template.HTML(`<a class="button" href="` + encodeHTMLEntities("mailto:"+conf.EmailAddress) + `">` +
+			encodeHTMLEntities(conf.EmailAddress) + `</a>`)
+	case EmailObfuscationJS:
+		return template.HTML(`<a class="button" id="email-button" href="#" data-email="` +
+			encodeEmailForJS(conf.EmailAddress) + `">Email</a>` +
+			`<script>(function () {
+                var a = document.getElementById("email-button");
+                var v = atob(a.dataset.email).split("").reverse().join("");
+                a.href = "mailto:" + v;
+                a.textContent = v;
+            })();</script>`)
+	case EmailObfuscationRedirect:
+		return template.HTML(`<a class="button" rel="nofollow" href="` + PathContact + `">Email</a>`)
+	}
+	return template.HTML(`<a class="button" href="mailto:` + template.HTMLEscapeString(conf.EmailAddress) + `">` +
+		template.HTMLEscapeString(conf.EmailAddress) + `</a>`)
+}
+
+const contactPageHTML = `<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta charset="utf-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <meta name="robots" content="noindex, nofollow" />
+    <title>Contact</title>
+    <style>
+        body { font-family: sans-serif; background-color: hsl(0, 0%, 10%); color: hsl(0, 0%, 85%); text-align: center; padding: 64px 16px; }
+        button { font: inherit; font-weight: bold; padding: 8px 12px; border: none; border-radius: 8px; background-color: hsl(260, 100%, 75%); cursor: pointer; }
+    </style>
+</head>
+<body>
+    <form method="POST" action="/contact">
+        <input type="hidden" name="confirm" value="yes">
+        <button type="submit">Write me an email</button>
+    </form>
+</body>
+</html>
+`
+
+// Serves a confirmation page (on GET) and redirects to the "mailto:" URL once confirmed (on POST).
+// The confirmation is a trivial check that most scrapers won't perform.
+func handleContact(emailAddress string) map[string]http.Handler {
+	return map[string]http.Handler{
+		"GET": http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			w.Header().Set("X-Robots-Tag", "noindex, nofollow")
+			w.WriteHeader(http.StatusOK)
+			io.WriteString(w, contactPageHTML)
+		}),
+		"POST": http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			r.Body = http.MaxBytesReader(w, r.Body, 1024)
+			if r.PostFormValue("confirm") != "yes" {
+				http.Error(w, "Bad request", http.StatusBadRequest)
+				return
+			}
+			http.Redirect(w, r, "mailto:"+emailAddress, http.StatusSeeOther)
+		}),
+	}
+}
@@ -7,18 +7,55 @@ import (
 	"html/template"
 	"io"
 	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ejuju/nubio/pkg/httpmux"
+	"github.com/ejuju/nubio/pkg/mdlite"
 )
 
 type ExportType string
 
 const (
-	ExportTypeHTML ExportType = "html"
-	ExportTypePDF  ExportType = "pdf"
-	ExportTypeJSON ExportType = "json"
+	ExportTypeHTML  ExportType = "html"
+	ExportTypePDF   ExportType = "pdf"
+	ExportTypeJSON  ExportType = "json"
+	ExportTypeVCard ExportType = "vcard"
 )
 
+type Exporter struct {
+	Type        ExportType
+	ContentType string
+	Filename    string // Used when exporting all formats to a directory.
+	Export      ExportFunc
+}
+
+// Lists supported export formats.
+var Exporters = []*Exporter{
+	{Type: ExportTypeHTML, ContentType: "text/html; charset=utf-8", Filename: "resume.html", Export: ExportHTML},
+	{Type: ExportTypePDF, ContentType: "application/pdf", Filename: "resume.pdf", Export: ExportPDF},
+	{Type: ExportTypeJSON, ContentType: "application/json", Filename: "resume.json", Export: ExportJSON},
+	{Type: ExportTypeVCard, ContentType: "text/vcard; charset=utf-8", Filename: "contact.vcf", Export: ExportVCard},
+}
+
+// Returns the exporter for the given type, or nil if the type is unknown.
+func GetExporter(typ ExportType) *Exporter {
+	for _, v := range Exporters {
+		if v.Type == typ {
+			return v
+		}
+	}
+	return nil
+}
+
 var tmplFuncs = template.FuncMap{
 	"subtract": func(a, b int) int { return a - b },
+	"join":     strings.Join,
+	"slugify":  httpmux.Slugify,
+	"markdown": mdlite.ToHTML,
+	"limit":    func(max int, v []string) []string { return limitHighlights(v, max) },
+	"url":      safeURL,
+	"icon":     linkIcon,
 }
 
 func mustParseHTMLTmpl(name, raw string) *template.Template {
@@ -31,36 +68,59 @@ var (
 	HTMLTemplate    = mustParseHTMLTmpl("html", HTMLRawTemplate)
 )
 
-type ExportFunc func(w io.Writer, conf *ResumeConfig) error
+// Note: Exporters render all fields of the given config,
+// use ResumeConfig.ForExport to omit fields that should not be visible.
+type ExportFunc func(w io.Writer, conf *ResumeConfig, opts ExportOptions) error
 
-func exportAndServe(conf *ResumeConfig, f ExportFunc, typ string) http.HandlerFunc {
+type ExportOptions struct {
+	// Optional: Time written in exports (ex: PDF creation date), defaults to the current time.
+	// Set it to produce reproducible exports (see DefaultExportOptions).
+	Time time.Time
+}
+
+func (opts ExportOptions) time() time.Time {
+	if opts.Time.IsZero() {
+		return time.Now()
+	}
+	return opts.Time
+}
+
+func exportAndServe(conf *ResumeConfig, typ ExportType) http.HandlerFunc {
+	exporter := GetExporter(typ)
 	buf := &bytes.Buffer{}
-	err := f(buf, conf)
+	opts := DefaultExportOptions()
+	err := exporter.Export(buf, conf.ForExport(typ, AudiencePublic, opts), opts)
 	if err != nil {
 		panic(err)
 	}
 
 	return func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Content-Type", typ)
+		w.Header().Set("Content-Type", exporter.ContentType)
 		w.WriteHeader(http.StatusOK)
 		w.Write(buf.Bytes())
 	}
 }
 
-func ExportHTML(w io.Writer, conf *ResumeConfig) error { return HTMLTemplate.Execute(w, conf) }
+func ExportHTML(w io.Writer, conf *ResumeConfig, _ ExportOptions) error {
+	return HTMLTemplate.Execute(w, conf)
+}
 
-func ExportJSON(w io.Writer, conf *ResumeConfig) error {
+func ExportJSON(w io.Writer, conf *ResumeConfig, _ ExportOptions) error {
 	return json.NewEncoder(w).Encode(conf.ToResumeExport())
 }
 
 func ExportAndServePDF(conf *ResumeConfig) http.HandlerFunc {
-	return exportAndServe(conf, ExportPDF, "application/pdf")
+	return exportAndServe(conf, ExportTypePDF)
 }
 
 func ExportAndServeHTML(conf *ResumeConfig) http.HandlerFunc {
-	return exportAndServe(conf, ExportHTML, "text/html; charset=utf-8")
+	return exportAndServe(conf, ExportTypeHTML)
 }
 
 func ExportAndServeJSON(conf *ResumeConfig) http.HandlerFunc {
-	return exportAndServe(conf, ExportJSON, "application/json")
+	return exportAndServe(conf, ExportTypeJSON)
+}
+
+func ExportAndServeVCard(conf *ResumeConfig) http.HandlerFunc {
+	return exportAndServe(conf, ExportTypeVCard)
 }
@@ -0,0 +1,176 @@
+package nubio
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+)
+
+const maxFontFileSize = 20_000_000 // In bytes (CJK fonts are usually below 20MB).
+
+// Paths to custom TrueType fonts used in the PDF and HTML exports (ex: for CJK characters or brand fonts). Not exported.
+// Note: Characters that are missing from the custom fonts are written with the embedded Noto Sans fonts in the PDF,
+// and with the default sans-serif font of the browser on the website.
+type Fonts struct {
+	RegularPath string `json:"regular_path"`
+	BoldPath    string `json:"bold_path"`   // Optional: The regular font is used for bold text if omitted.
+	ItalicPath  string `json:"italic_path"` // Optional: Used for emphasis (written as regular text if omitted).
+	Regular     []byte `json:"-"`           // Populated by the corresponding file's content on load.
+	Bold        []byte `json:"-"`           // Populated by the corresponding file's content on load.
+	Italic      []byte `json:"-"`           // Populated by the corresponding file's content on load.
+}
+
+func (f *Fonts) Check() (errs []error) {
+	if f.RegularPath == "" && (f.BoldPath != "" || f.ItalicPath != "") {
+		errs = append(errs, errors.New("missing regular font path"))
+	}
+	return errs
+}
+
+// Returns the provided font files by URL path (ex: "/fonts/regular.ttf").
+func (f *Fonts) Files() map[string][]byte {
+	files := map[string][]byte{}
+	for path, b := range map[string][]byte{PathFontRegular: f.Regular, PathFontBold: f.Bold, PathFontItalic: f.Italic} {
+		if len(b) > 0 {
+			files[path] = b
+		}
+	}
+	return files
+}
+
+func (f *Fonts) load() (err error) {
+	for _, v := range []struct {
+		name string
+		path string
+		dst  *[]byte
+	}{
+		{"regular", f.RegularPath, &f.Regular},
+		{"bold", f.BoldPath, &f.Bold},
+		{"italic", f.ItalicPath, &f.Italic},
+	} {
+		if v.path == "" {
+			continue
+		}
+		*v.dst, err = loadFont(v.path)
+		if err != nil {
+			return fmt.Errorf("%s font: %w", v.name, err)
+		}
+	}
+	return nil
+}
+
+// Reads and validates the TrueType font at the given path.
+func loadFont(path string) (b []byte, err error) {
+	b, err = os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) > maxFontFileSize {
+		return nil, fmt.Errorf("file is too big: %d bytes (max %d)", len(b), maxFontFileSize)
+	}
+	_, err = parseFontGlyphs(b)
+	if err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// Set of characters of the Basic Multilingual Plane (as bits).
+type runeSet [0x10000 / 64]uint64
+
+func (s *runeSet) add(r rune)           { s[r/64] |= 1 << (r % 64) }
+func (s *runeSet) contains(r rune) bool { return r < 0x10000 && s[r/64]&(1<<(r%64)) != 0 }
+
+func (s *runeSet) containsAll(txt string) bool {
+	for _, r := range txt {
+		if !s.contains(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// Returns the characters that have a glyph in the given TrueType font.
+// Note: Like fpdf, only the Unicode BMP character map (format 4) is supported.
+func parseFontGlyphs(b []byte) (glyphs *runeSet, err error) {
+	errMalformed := errors.New("malformed font file")
+	u16 := func(off int) int {
+		if off < 0 || off+2 > len(b) {
+			err = errMalformed
+			return 0
+		}
+		return int(binary.BigEndian.Uint16(b[off:]))
+	}
+	u32 := func(off int) int {
+		if off < 0 || off+4 > len(b) {
+			err = errMalformed
+			return 0
+		}
+		return int(binary.BigEndian.Uint32(b[off:]))
+	}
+
+	// Find tables.
+	if len(b) < 12 {
+		return nil, errMalformed
+	}
+	if version := string(b[:4]); version != "\x00\x01\x00\x00" && version != "true" {
+		return nil, errors.New("unsupported font format (only TrueType fonts are supported)")
+	}
+	tables := map[string]int{}
+	for i := range u16(4) {
+		off := 12 + i*16
+		if off+16 > len(b) {
+			return nil, errMalformed
+		}
+		tables[string(b[off:off+4])] = u32(off + 8)
+	}
+	for _, tag := range []string{"cmap", "glyf", "head", "hhea", "hmtx", "loca", "maxp"} {
+		if _, ok := tables[tag]; !ok {
+			return nil, fmt.Errorf("missing %q table (only TrueType fonts are supported)", tag)
+		}
+	}
+
+	// Find Unicode BMP character map.
+	cmap, sub := tables["cmap"], -1
+	for i := range u16(cmap + 2) {
+		rec := cmap + 4 + i*8
+		platform, encoding, off := u16(rec), u16(rec+2), u32(rec+4)
+		if (platform == 3 && encoding == 1 || platform == 0) && u16(cmap+off) == 4 {
+			sub = cmap + off
+			break
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	if sub < 0 {
+		return nil, errors.New("missing Unicode character map")
+	}
+
+	// Parse segments (see https://learn.microsoft.com/en-us/typography/opentype/spec/cmap#format-4-segment-mapping-to-delta-values).
+	glyphs = &runeSet{}
+	segCountX2 := u16(sub + 6)
+	ends, starts := sub+14, sub+16+segCountX2
+	deltas, rangeOffsets := starts+segCountX2, starts+2*segCountX2
+	for seg := 0; seg < segCountX2; seg += 2 {
+		start, end := u16(starts+seg), u16(ends+seg)
+		delta, rangeOffset := u16(deltas+seg), u16(rangeOffsets+seg)
+		for c := start; c <= end && c < 0xFFFF; c++ {
+			glyph := c
+			if rangeOffset != 0 {
+				glyph = u16(rangeOffsets + seg + rangeOffset + 2*(c-start))
+				if glyph == 0 {
+					continue
+				}
+			}
+			if (glyph+delta)&0xFFFF != 0 {
+				glyphs.add(rune(c))
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return glyphs, nil
+}
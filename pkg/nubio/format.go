@@ -0,0 +1,272 @@
+package nubio
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+)
+
+type FormatOptions struct {
+	// Set to true to sort work experience, projects and education entries reverse-chronologically
+	// (current entries first, then by end date and start date).
+	SortByDate bool
+}
+
+// Accepted date layouts (in addition to DateLayout), normalized to DateLayout when formatting.
+var looseDateLayouts = []string{
+	"Jan 2006",
+	"January, 2006",
+	"Jan. 2006",
+	"2006-01",
+	"2006/01",
+	"01/2006",
+	"1/2006",
+	"2006-01-02",
+	"January 2, 2006",
+	"Jan 2, 2006",
+}
+
+// Formats a resume config file:
+//   - keys are written in the order of the ResumeConfig fields (with an indentation of 4 spaces),
+//   - unknown keys are kept as is (after the known ones, sorted),
+//   - empty fields are omitted (and tools without details are written as strings),
+//   - dates are normalized to DateLayout (ex: "2021-05" becomes "May 2021").
+//
+// An error is returned if an entry has unknown fields (they would be dropped).
+//
+// Note: The config is only decoded (not loaded), referenced files (ex: avatar) are left as is.
+func FormatResumeConfig(b []byte, opts FormatOptions) ([]byte, error) {
+	// Set aside unknown keys and decode the known ones.
+	doc := map[string]json.RawMessage{}
+	err := json.Unmarshal(b, &doc)
+	if err != nil {
+		return nil, fmt.Errorf("decode config: %w", err)
+	}
+	unknown := map[string]json.RawMessage{}
+	for _, k := range unknownKeys(doc, ResumeConfig{}) {
+		unknown[k] = doc[k]
+		delete(doc, k)
+	}
+	b, err = json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	conf := &ResumeConfig{}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.DisallowUnknownFields()
+	err = dec.Decode(conf)
+	if err != nil {
+		return nil, fmt.Errorf("decode config: %w", err)
+	}
+
+	normalizeResumeDates(conf)
+	if opts.SortByDate {
+		slices.SortStableFunc(conf.WorkExperience, func(a, b WorkExperience) int { return compareDateRanges(b.From, b.To, a.From, a.To) })
+		slices.SortStableFunc(conf.Projects, func(a, b Project) int { return compareDateRanges(b.From, b.To, a.From, a.To) })
+		slices.SortStableFunc(conf.Education, func(a, b Education) int { return compareDateRanges(b.From, b.To, a.From, a.To) })
+	}
+
+	buf := &bytes.Buffer{}
+	err = writeFormattedJSON(buf, reflect.ValueOf(conf).Elem(), "")
+	if err != nil {
+		return nil, err
+	}
+	err = writeUnknownKeys(buf, unknown)
+	if err != nil {
+		return nil, err
+	}
+	buf.WriteByte('\n')
+	return buf.Bytes(), nil
+}
+
+// Appends the keys (and their raw value, indented) to the formatted JSON object in the buffer.
+func writeUnknownKeys(buf *bytes.Buffer, unknown map[string]json.RawMessage) error {
+	const indentUnit = "    "
+	if len(unknown) == 0 {
+		return nil
+	}
+	buf.Truncate(buf.Len() - len("}"))
+	if buf.Len() > len("{") {
+		buf.Truncate(buf.Len() - len("\n"))
+		buf.WriteByte(',')
+	}
+	buf.WriteByte('\n')
+	for i, k := range slices.Sorted(maps.Keys(unknown)) {
+		name, err := json.Marshal(k)
+		if err != nil {
+			return err
+		}
+		buf.WriteString(indentUnit)
+		buf.Write(name)
+		buf.WriteString(": ")
+		err = json.Indent(buf, unknown[k], indentUnit, indentUnit)
+		if err != nil {
+			return err
+		}
+		if i < len(unknown)-1 {
+			buf.WriteByte(',')
+		}
+		buf.WriteByte('\n')
+	}
+	buf.WriteByte('}')
+	return nil
+}
+
+func normalizeResumeDates(conf *ResumeConfig) {
+	for i := range conf.WorkExperience {
+		normalizeDates(&conf.WorkExperience[i].From, &conf.WorkExperience[i].To)
+	}
+	for i := range conf.Projects {
+		normalizeDates(&conf.Projects[i].From, &conf.Projects[i].To)
+	}
+	for i := range conf.Education {
+		normalizeDates(&conf.Education[i].From, &conf.Education[i].To)
+	}
+	for i := range conf.Certifications {
+		normalizeDates(&conf.Certifications[i].Date, &conf.Certifications[i].Expiry)
+	}
+	for i := range conf.Publications {
+		normalizeDates(&conf.Publications[i].Date)
+	}
+	for i := range conf.Talks {
+		normalizeDates(&conf.Talks[i].Date)
+	}
+	for i := range conf.Awards {
+		normalizeDates(&conf.Awards[i].Date)
+	}
+	for i := range conf.CustomSections {
+		for j := range conf.CustomSections[i].Entries {
+			entry := &conf.CustomSections[i].Entries[j]
+			normalizeDates(&entry.From, &entry.To)
+		}
+	}
+}
+
+// Rewrites dates using DateLayout, dates that can't be parsed are left as is.
+func normalizeDates(dates ...*string) {
+	for _, date := range dates {
+		raw := strings.TrimSpace(*date)
+		if strings.EqualFold(raw, "now") {
+			*date = "now"
+			continue
+		}
+		for _, layout := range append([]string{DateLayout}, looseDateLayouts...) {
+			t, err := time.Parse(layout, raw)
+			if err == nil {
+				*date = t.Format(DateLayout)
+				break
+			}
+		}
+	}
+}
+
+// Compares date ranges by end date (with "now" or a missing end date being the latest), then by start date.
+// Note: Dates that can't be parsed are considered the earliest.
+func compareDateRanges(fromA, toA, fromB, toB string) int {
+	parse := func(raw string, fallback time.Time) time.Time {
+		if raw == "now" || raw == "" {
+			return fallback
+		}
+		t, err := time.Parse(DateLayout, raw)
+		if err != nil {
+			return time.Time{}
+		}
+		return t
+	}
+	return cmp.Or(
+		parse(toA, maxExpDate).Compare(parse(toB, maxExpDate)),
+		parse(fromA, time.Time{}).Compare(parse(fromB, time.Time{})),
+	)
+}
+
+// Writes the value as indented JSON, in struct field order and omitting empty fields.
+func writeFormattedJSON(buf *bytes.Buffer, v reflect.Value, indent string) error {
+	const indentUnit = "    "
+
+	// Write tools without details as strings (ex: "Go").
+	if tool, ok := v.Interface().(Tool); ok && tool == (Tool{Name: tool.Name}) {
+		v = reflect.ValueOf(tool.Name)
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		fields := []int{}
+		for i := 0; i < v.NumField(); i++ {
+			name, _, _ := strings.Cut(v.Type().Field(i).Tag.Get("json"), ",")
+			if name != "" && name != "-" && !isEmptyFormattedValue(v.Field(i)) {
+				fields = append(fields, i)
+			}
+		}
+		if len(fields) == 0 {
+			buf.WriteString("{}")
+			return nil
+		}
+		buf.WriteString("{\n")
+		for i, field := range fields {
+			name, _, _ := strings.Cut(v.Type().Field(field).Tag.Get("json"), ",")
+			fmt.Fprintf(buf, "%s%s%q: ", indent, indentUnit, name)
+			err := writeFormattedJSON(buf, v.Field(field), indent+indentUnit)
+			if err != nil {
+				return err
+			}
+			if i < len(fields)-1 {
+				buf.WriteByte(',')
+			}
+			buf.WriteByte('\n')
+		}
+		buf.WriteString(indent + "}")
+		return nil
+	case reflect.Slice:
+		if v.Len() == 0 {
+			buf.WriteString("[]")
+			return nil
+		}
+		buf.WriteString("[\n")
+		for i := 0; i < v.Len(); i++ {
+			buf.WriteString(indent + indentUnit)
+			err := writeFormattedJSON(buf, v.Index(i), indent+indentUnit)
+			if err != nil {
+				return err
+			}
+			if i < v.Len()-1 {
+				buf.WriteByte(',')
+			}
+			buf.WriteByte('\n')
+		}
+		buf.WriteString(indent + "]")
+		return nil
+	}
+
+	// Encode other values as is (without escaping HTML characters).
+	leaf := &bytes.Buffer{}
+	enc := json.NewEncoder(leaf)
+	enc.SetEscapeHTML(false)
+	err := enc.Encode(v.Interface())
+	if err != nil {
+		return err
+	}
+	buf.Write(bytes.TrimSuffix(leaf.Bytes(), []byte("\n")))
+	return nil
+}
+
+// Reports whether the value is a zero value, an empty slice, or a struct with only empty fields.
+func isEmptyFormattedValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Slice, reflect.Map:
+		return v.Len() == 0
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() && !isEmptyFormattedValue(v.Field(i)) {
+				return false
+			}
+		}
+		return true
+	}
+	return v.IsZero()
+}
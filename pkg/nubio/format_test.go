@@ -0,0 +1,58 @@
+package nubio
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestFormatResumeConfig(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want string
+	}{
+		{`{"name": "Jane Doe"}`, "{\n    \"name\": \"Jane Doe\"\n}\n"},
+		{
+			`{"x_extra": {"a": [1, 2]}, "name": "Jane Doe", "a_extra": true}`,
+			"{\n    \"name\": \"Jane Doe\",\n    \"a_extra\": true,\n    \"x_extra\": {\n        \"a\": [\n            1,\n            2\n        ]\n    }\n}\n",
+		},
+		{`{"extra": 1}`, "{\n    \"extra\": 1\n}\n"},
+		{`{"work_experience": [{"title": "Engineer", "from": "2021-05", "to": "now"}]}`,
+			"{\n    \"work_experience\": [\n        {\n            \"from\": \"May 2021\",\n            \"to\": \"now\",\n            \"title\": \"Engineer\"\n        }\n    ]\n}\n",
+		},
+	}
+	for _, test := range tests {
+		got, err := FormatResumeConfig([]byte(test.raw), FormatOptions{})
+		if err != nil {
+			t.Fatalf("%s: %s", test.raw, err)
+		}
+		if string(got) != test.want {
+			t.Errorf("%s: want:\n%s\ngot:\n%s", test.raw, test.want, got)
+		}
+		again, err := FormatResumeConfig(got, FormatOptions{})
+		if err != nil || string(again) != string(got) {
+			t.Errorf("%s: formatting is not idempotent: %s (error: %v)", test.raw, again, err)
+		}
+	}
+}
+
+func TestFormatResumeConfigRefusesUnknownNestedFields(t *testing.T) {
+	_, err := FormatResumeConfig([]byte(`{"work_experience": [{"title": "Engineer", "team": "Core"}]}`), FormatOptions{})
+	if err == nil || !strings.Contains(err.Error(), "team") {
+		t.Fatalf("want unknown field error, got %v", err)
+	}
+}
+
+func TestFormatResumeConfigExample(t *testing.T) {
+	b, err := os.ReadFile("../../resume.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := FormatResumeConfig(b, FormatOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != string(b) {
+		t.Fatalf("example resume config is not formatted")
+	}
+}
@@ -5,21 +5,29 @@ import (
 	_ "embed"
 	"log/slog"
 	"net/http"
+	"time"
 
 	"github.com/ejuju/nubio/pkg/httpmux"
 )
 
 const (
-	PathPing       = "/ping"
-	PathVersion    = "/version"
-	PathFaviconSVG = "/favicon.svg"
-	PathSitemapXML = "/sitemap.xml"
-	PathRobotsTXT  = "/robots.txt"
-	PathResumeHTML = "/"
-	PathResumeJSON = "/resume.json"
-	PathResumePDF  = "/resume.pdf"
-	PathPGPKey     = "/pgp.asc"
-	PathCustomCSS  = "/custom.css"
+	PathPing        = "/ping"
+	PathVersion     = "/version"
+	PathFaviconSVG  = "/favicon.svg"
+	PathSitemapXML  = "/sitemap.xml"
+	PathRobotsTXT   = "/robots.txt"
+	PathResumeHTML  = "/"
+	PathResumeJSON  = "/resume.json"
+	PathResumePDF   = "/resume.pdf"
+	PathVCard       = "/contact.vcf"
+	PathContact     = "/contact"
+	PathPGPKey      = "/pgp.asc"
+	PathCustomCSS   = "/custom.css"
+	PathAvatarJPEG  = "/avatar.jpg"
+	PathAvatarPNG   = "/avatar.png"
+	PathFontRegular = "/fonts/regular.ttf"
+	PathFontBold    = "/fonts/bold.ttf"
+	PathFontItalic  = "/fonts/italic.ttf"
 )
 
 func NewHTTPHandler(fallback http.Handler, conf *ResumeConfig) http.Handler {
@@ -32,13 +40,23 @@ func NewHTTPHandler(fallback http.Handler, conf *ResumeConfig) http.Handler {
 		PathResumeHTML: {"GET": ExportAndServeHTML(conf)},
 		PathResumePDF:  {"GET": ExportAndServePDF(conf)},
 		PathResumeJSON: {"GET": ExportAndServeJSON(conf)},
+		PathVCard:      {"GET": ExportAndServeVCard(conf)},
 	}
 	if len(conf.PGPKey) > 0 {
 		m[PathPGPKey] = map[string]http.Handler{"GET": httpmux.TextHandler(string(conf.PGPKey))}
 	}
+	if conf.EmailObfuscation == EmailObfuscationRedirect {
+		m[PathContact] = handleContact(conf.EmailAddress)
+	}
+	if len(conf.Avatar) > 0 {
+		m[conf.AvatarURLPath()] = map[string]http.Handler{"GET": httpmux.CachedHandler(conf.Avatar, "image/"+conf.AvatarFormat, 24*time.Hour)}
+	}
 	if len(conf.CustomCSS) > 0 {
 		m[PathCustomCSS] = map[string]http.Handler{"GET": httpmux.CSSHandler([]byte(conf.CustomCSS))}
 	}
+	for path, b := range conf.Fonts.Files() {
+		m[path] = map[string]http.Handler{"GET": httpmux.CachedHandler(b, "font/ttf", 24*time.Hour)}
+	}
 
 	return m.Handler(fallback)
 }
@@ -0,0 +1,41 @@
+package nubio
+
+import "github.com/ejuju/nubio/pkg/mdlite"
+
+// Returns structured data (schema.org "Person") to be embedded in the HTML page as JSON-LD.
+// Note: The email address is omitted if it is obfuscated.
+func (conf *ResumeConfig) JSONLD() map[string]any {
+	v := map[string]any{
+		"@context": "https://schema.org",
+		"@type":    "Person",
+		"name":     conf.Name,
+		"url":      "https://" + conf.Domain,
+	}
+	if conf.Description != "" {
+		v["jobTitle"] = mdlite.ToPlainText(conf.Description)
+	}
+	if conf.EmailObfuscation == EmailObfuscationNone {
+		v["email"] = "mailto:" + conf.EmailAddress
+	}
+	if len(conf.ContactDetails.Phones) > 0 {
+		v["telephone"] = conf.ContactDetails.Phones[0].Number
+	}
+	if loc := conf.ContactDetails.Location; loc.City != "" || loc.Country != "" {
+		v["address"] = map[string]any{
+			"@type":           "PostalAddress",
+			"addressLocality": loc.City,
+			"addressCountry":  loc.Country,
+		}
+	}
+	if len(conf.Links) > 0 {
+		sameAs := make([]string, 0, len(conf.Links))
+		for _, link := range conf.Links {
+			if link.Kind == LinkKindEmail || link.Kind == LinkKindPhone {
+				continue // Note: "sameAs" is meant for web pages.
+			}
+			sameAs = append(sameAs, link.URL)
+		}
+		v["sameAs"] = sameAs
+	}
+	return v
+}
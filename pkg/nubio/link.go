@@ -0,0 +1,203 @@
+package nubio
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"html/template"
+	"net"
+	"net/mail"
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/idna"
+)
+
+// Link kinds (used to pick an icon, auto-detected from the URL if not provided).
+const (
+	LinkKindWebsite  = "website"
+	LinkKindEmail    = "email"
+	LinkKindPhone    = "phone"
+	LinkKindGitHub   = "github"
+	LinkKindGitLab   = "gitlab"
+	LinkKindLinkedIn = "linkedin"
+	LinkKindMastodon = "mastodon"
+	LinkKindBluesky  = "bluesky"
+	LinkKindX        = "x"
+	LinkKindYouTube  = "youtube"
+)
+
+var linkKinds = []string{
+	LinkKindWebsite,
+	LinkKindEmail,
+	LinkKindPhone,
+	LinkKindGitHub,
+	LinkKindGitLab,
+	LinkKindLinkedIn,
+	LinkKindMastodon,
+	LinkKindBluesky,
+	LinkKindX,
+	LinkKindYouTube,
+}
+
+var linkKindsByHost = map[string]string{
+	"github.com":   LinkKindGitHub,
+	"gitlab.com":   LinkKindGitLab,
+	"linkedin.com": LinkKindLinkedIn,
+	"bsky.app":     LinkKindBluesky,
+	"x.com":        LinkKindX,
+	"twitter.com":  LinkKindX,
+	"youtube.com":  LinkKindYouTube,
+	"youtu.be":     LinkKindYouTube,
+}
+
+// Guesses the link kind from the URL, defaults to "website".
+func detectLinkKind(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return LinkKindWebsite
+	}
+	switch u.Scheme {
+	case "mailto":
+		return LinkKindEmail
+	case "tel":
+		return LinkKindPhone
+	}
+	host := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+	if kind, ok := linkKindsByHost[host]; ok {
+		return kind
+	}
+	if strings.HasPrefix(u.Path, "/@") {
+		return LinkKindMastodon // Best effort: Mastodon instances can use any domain.
+	}
+	return LinkKindWebsite
+}
+
+// Adds the "https://" scheme to scheme-less (legacy) URLs (ex: "github.com/alexdoe" or "localhost:8080/x").
+// URLs that already have a scheme (ex: "mailto:alex@example.com") are returned as is.
+// Note: Only "mailto" and "tel" are recognized as schemes without a following "//".
+func normalizeURL(raw string) string {
+	if raw == "" {
+		return ""
+	}
+	scheme, rest, hasScheme := strings.Cut(raw, ":")
+	if hasScheme && scheme != "" && !strings.ContainsAny(scheme, "/.") {
+		switch {
+		case strings.HasPrefix(rest, "//"), strings.EqualFold(scheme, "mailto"), strings.EqualFold(scheme, "tel"):
+			return raw
+		}
+	}
+	return "https://" + strings.TrimPrefix(raw, "//")
+}
+
+// Normalizes all URLs of the resume config and detects missing link kinds.
+func normalizeResumeURLs(conf *ResumeConfig) {
+	normalizeLinks := func(links []Link) {
+		for i := range links {
+			links[i].URL = normalizeURL(links[i].URL)
+			if links[i].Kind == "" {
+				links[i].Kind = detectLinkKind(links[i].URL)
+			}
+		}
+	}
+	normalizeLinks(conf.Links)
+	for i := range conf.CustomSections {
+		for j := range conf.CustomSections[i].Entries {
+			normalizeLinks(conf.CustomSections[i].Entries[j].Links)
+		}
+	}
+	for i := range conf.Projects {
+		conf.Projects[i].URL = normalizeURL(conf.Projects[i].URL)
+		conf.Projects[i].Repository = normalizeURL(conf.Projects[i].Repository)
+	}
+	for i := range conf.Certifications {
+		conf.Certifications[i].CredentialURL = normalizeURL(conf.Certifications[i].CredentialURL)
+	}
+	for i := range conf.Publications {
+		conf.Publications[i].URL = normalizeURL(conf.Publications[i].URL)
+	}
+	for i := range conf.Talks {
+		conf.Talks[i].URL = normalizeURL(conf.Talks[i].URL)
+	}
+}
+
+var errMissingURLScheme = errors.New("missing URL scheme")
+
+// Checks that the URL is absolute and uses a supported scheme ("http", "https", "mailto" or "tel"),
+// that HTTP(S) URLs have a valid host, and that email addresses and phone numbers are valid.
+func checkURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return err
+	}
+	switch u.Scheme {
+	case "":
+		return errMissingURLScheme
+	case "http", "https":
+		return checkHost(u.Hostname())
+	case "mailto":
+		if _, err := mail.ParseAddress(u.Opaque); err != nil {
+			return fmt.Errorf("invalid email address: %w", err)
+		}
+		return nil
+	case "tel":
+		number := strings.NewReplacer("-", "", ".", "", " ", "", "(", "", ")", "").Replace(u.Opaque)
+		if !e164Regexp.MatchString(number) {
+			return fmt.Errorf("phone number is not in E.164 format: %q", u.Opaque)
+		}
+		return nil
+	}
+	return fmt.Errorf("unsupported URL scheme: %q", u.Scheme)
+}
+
+// Accepts IP addresses, "localhost", and (internationalized) domain names with at least two labels.
+func checkHost(host string) error {
+	if host == "" {
+		return errors.New("missing host")
+	}
+	if host == "localhost" || net.ParseIP(host) != nil {
+		return nil
+	}
+	ascii, err := idna.Lookup.ToASCII(host)
+	if err != nil {
+		return fmt.Errorf("invalid host: %w", err)
+	}
+	if !strings.Contains(strings.TrimSuffix(ascii, "."), ".") {
+		return fmt.Errorf("invalid host: %q is not a fully qualified domain name", host)
+	}
+	return nil
+}
+
+// Returns a shorter version of the URL for display (without the scheme and trailing slash).
+func displayURL(raw string) string {
+	for _, prefix := range []string{"https://", "http://", "mailto:", "tel:"} {
+		if v, ok := strings.CutPrefix(raw, prefix); ok {
+			return strings.TrimSuffix(v, "/")
+		}
+	}
+	return raw
+}
+
+// Returns the URL as a trusted template value (so that "tel:" URLs are not filtered out by html/template).
+// Invalid URLs are replaced by "#".
+func safeURL(raw string) template.URL {
+	if checkURL(raw) != nil {
+		return "#"
+	}
+	return template.URL(raw)
+}
+
+// Reports whether the link points to a web page that may link back to the resume (for "rel=me" verification).
+func (v Link) IsProfile() bool { return v.Kind != LinkKindEmail && v.Kind != LinkKindPhone }
+
+//go:embed icons/*.svg
+var iconsFS embed.FS
+
+// Returns the SVG icon for the given link kind (defaults to the "website" icon).
+func linkIcon(kind string) template.HTML {
+	b, err := iconsFS.ReadFile("icons/" + kind + ".svg")
+	if err != nil {
+		b, _ = iconsFS.ReadFile("icons/" + LinkKindWebsite + ".svg")
+	}
+	return template.HTML(b)
+}
@@ -0,0 +1,25 @@
+package nubio
+
+import "testing"
+
+func TestNormalizeURL(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want string
+	}{
+		{"", ""},
+		{"github.com/alexdoe", "https://github.com/alexdoe"},
+		{"//example.com", "https://example.com"},
+		{"https://example.com", "https://example.com"},
+		{"http://example.com", "http://example.com"},
+		{"localhost:8080/x", "https://localhost:8080/x"},
+		{"example.com:8080", "https://example.com:8080"},
+		{"mailto:alex@example.com", "mailto:alex@example.com"},
+		{"tel:+33612345678", "tel:+33612345678"},
+	}
+	for _, test := range tests {
+		if got := normalizeURL(test.raw); got != test.want {
+			t.Errorf("%q: want %q, got %q", test.raw, test.want, got)
+		}
+	}
+}
@@ -0,0 +1,205 @@
+package nubio
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+// Checks that URLs resolve (using a HEAD request, and a GET request if HEAD is not supported).
+// The zero value is ready to use.
+type LinkChecker struct {
+	Transport    http.RoundTripper // Optional: Defaults to http.DefaultTransport.
+	Timeout      time.Duration     // Optional: Timeout for each URL (including redirects), defaults to 10 seconds.
+	Concurrency  int               // Optional: Max number of concurrent requests, defaults to 8.
+	MaxRedirects int               // Optional: Defaults to 10.
+	UserAgent    string            // Optional: Defaults to "nubio-link-checker".
+}
+
+// Identifies an URL found in the resume config.
+type LinkCheckTarget struct {
+	Source string `json:"source"` // Name of the field holding the URL (ex: "links[0]").
+	URL    string `json:"url"`
+}
+
+type LinkCheckResult struct {
+	LinkCheckTarget
+	OK         bool     `json:"ok"`                  // True if the URL resolved to a successful (2xx) response.
+	StatusCode int      `json:"status_code"`         // Status code of the last response (0 if no response was received).
+	Redirects  []string `json:"redirects,omitempty"` // URLs the link redirected to (in order).
+	Error      string   `json:"error,omitempty"`
+}
+
+// Returns the final URL (after redirects).
+func (v *LinkCheckResult) FinalURL() string {
+	if len(v.Redirects) == 0 {
+		return v.URL
+	}
+	return v.Redirects[len(v.Redirects)-1]
+}
+
+// Returns all HTTP(S) URLs of the resume config.
+// Note: "mailto:" and "tel:" URLs are ignored.
+func CollectLinkCheckTargets(conf *ResumeConfig) (targets []LinkCheckTarget) {
+	add := func(source, rawURL string) {
+		if rawURL == "" {
+			return
+		}
+		rawURL = normalizeURL(rawURL)
+		if !strings.HasPrefix(rawURL, "https://") && !strings.HasPrefix(rawURL, "http://") {
+			return
+		}
+		targets = append(targets, LinkCheckTarget{Source: source, URL: rawURL})
+	}
+
+	add("pgp_key_url", conf.PGPKeyURL)
+	for i, v := range conf.Links {
+		add(fmt.Sprintf("links[%d]", i), v.URL)
+	}
+	for i, v := range conf.Projects {
+		add(fmt.Sprintf("projects[%d].url", i), v.URL)
+		add(fmt.Sprintf("projects[%d].repository", i), v.Repository)
+	}
+	for i, v := range conf.Certifications {
+		add(fmt.Sprintf("certifications[%d].credential_url", i), v.CredentialURL)
+	}
+	for i, v := range conf.Publications {
+		add(fmt.Sprintf("publications[%d].url", i), v.URL)
+	}
+	for i, v := range conf.Talks {
+		add(fmt.Sprintf("talks[%d].url", i), v.URL)
+	}
+	for i, section := range conf.CustomSections {
+		for j, entry := range section.Entries {
+			for k, v := range entry.Links {
+				add(fmt.Sprintf("custom_sections[%d].entries[%d].links[%d]", i, j, k), v.URL)
+			}
+		}
+	}
+	return targets
+}
+
+// Checks all targets concurrently, results are returned in the same order as the targets.
+func (lc *LinkChecker) Check(ctx context.Context, targets []LinkCheckTarget) []LinkCheckResult {
+	concurrency := lc.Concurrency
+	if concurrency <= 0 {
+		concurrency = 8
+	}
+
+	results := make([]LinkCheckResult, len(targets))
+	sem := make(chan struct{}, concurrency)
+	wg := sync.WaitGroup{}
+	for i, target := range targets {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer func() { <-sem; wg.Done() }()
+			results[i] = lc.CheckURL(ctx, target)
+		}()
+	}
+	wg.Wait()
+	return results
+}
+
+// Checks a single URL.
+func (lc *LinkChecker) CheckURL(ctx context.Context, target LinkCheckTarget) (result LinkCheckResult) {
+	result.LinkCheckTarget = target
+
+	timeout := lc.Timeout
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	// Try HEAD first, and fallback to GET for servers that don't support HEAD requests.
+	for _, method := range []string{http.MethodHead, http.MethodGet} {
+		result.Redirects = nil
+		result.StatusCode, result.Error = 0, ""
+		statusCode, err := lc.do(ctx, method, target.URL, &result.Redirects)
+		result.StatusCode = statusCode
+		if err != nil {
+			result.Error = err.Error()
+		}
+		if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+			break
+		}
+		if method == http.MethodHead && (err != nil || statusCode >= 400) {
+			continue
+		}
+		break
+	}
+	result.OK = result.Error == "" && result.StatusCode >= 200 && result.StatusCode < 300
+	return result
+}
+
+var errTooManyRedirects = errors.New("too many redirects")
+
+func (lc *LinkChecker) do(ctx context.Context, method, rawURL string, redirects *[]string) (statusCode int, err error) {
+	maxRedirects := lc.MaxRedirects
+	if maxRedirects <= 0 {
+		maxRedirects = 10
+	}
+	userAgent := lc.UserAgent
+	if userAgent == "" {
+		userAgent = "nubio-link-checker"
+	}
+	client := &http.Client{
+		Transport: lc.Transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) > maxRedirects {
+				return errTooManyRedirects
+			}
+			*redirects = append(*redirects, req.URL.String())
+			return nil
+		},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, rawURL, nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("User-Agent", userAgent)
+	res, err := client.Do(req)
+	if err != nil {
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err // Note: The URL is already reported.
+		}
+		return 0, err
+	}
+	defer res.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(res.Body, 64*1024)) // Allow connection re-use.
+	return res.StatusCode, nil
+}
+
+// Writes the results as a human-readable table.
+func WriteLinkCheckTable(w io.Writer, results []LinkCheckResult) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "STATUS\tSOURCE\tURL\tDETAILS")
+	for _, v := range results {
+		status := "ok"
+		if !v.OK {
+			status = "broken"
+		}
+		details := ""
+		if v.StatusCode != 0 {
+			details = fmt.Sprintf("%d %s", v.StatusCode, http.StatusText(v.StatusCode))
+		}
+		if v.Error != "" {
+			details = strings.TrimSpace(details + " " + v.Error)
+		}
+		if len(v.Redirects) > 0 {
+			details += fmt.Sprintf(" (redirected to %s)", v.FinalURL())
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", status, v.Source, v.URL, details)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,74 @@
+package nubio
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLinkChecker(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ok", func(w http.ResponseWriter, r *http.Request) {})
+	mux.HandleFunc("/no-head", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	})
+	mux.HandleFunc("/not-found", http.NotFound)
+	mux.HandleFunc("/error", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	mux.HandleFunc("/redirect/{n}", func(w http.ResponseWriter, r *http.Request) {
+		n, _ := strconv.Atoi(r.PathValue("n"))
+		if n == 0 {
+			return
+		}
+		http.Redirect(w, r, "/redirect/"+strconv.Itoa(n-1), http.StatusFound)
+	})
+	mux.HandleFunc("/slow", func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	lc := &LinkChecker{Timeout: 500 * time.Millisecond, MaxRedirects: 3}
+	tests := []struct {
+		path       string
+		ok         bool
+		statusCode int
+		redirects  int
+		err        string
+	}{
+		{path: "/ok", ok: true, statusCode: 200},
+		{path: "/no-head", ok: true, statusCode: 200},
+		{path: "/not-found", statusCode: 404},
+		{path: "/error", statusCode: 500},
+		{path: "/redirect/3", ok: true, statusCode: 200, redirects: 3},
+		{path: "/redirect/4", redirects: 3, err: errTooManyRedirects.Error()},
+		{path: "/slow", err: context.DeadlineExceeded.Error()},
+	}
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			got := lc.CheckURL(context.Background(), LinkCheckTarget{URL: srv.URL + test.path})
+			if got.OK != test.ok {
+				t.Errorf("want ok %v, got %v (error: %q)", test.ok, got.OK, got.Error)
+			}
+			if got.StatusCode != test.statusCode {
+				t.Errorf("want status code %d, got %d", test.statusCode, got.StatusCode)
+			}
+			if len(got.Redirects) != test.redirects {
+				t.Errorf("want %d redirects, got %d: %v", test.redirects, len(got.Redirects), got.Redirects)
+			}
+			if !strings.Contains(got.Error, test.err) || (test.err == "" && got.Error != "") {
+				t.Errorf("want error %q, got %q", test.err, got.Error)
+			}
+		})
+	}
+}
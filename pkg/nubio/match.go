@@ -0,0 +1,369 @@
+package nubio
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/ejuju/nubio/pkg/mdlite"
+)
+
+// Default synonyms used to match job postings (canonical name to aliases).
+// Note: Matching is case-insensitive.
+var DefaultSynonyms = map[string][]string{
+	"Go":               {"golang"},
+	"JavaScript":       {"js", "ecmascript"},
+	"TypeScript":       {"ts"},
+	"Node.js":          {"nodejs", "node"},
+	"Kubernetes":       {"k8s"},
+	"Postgres":         {"postgresql"},
+	"CICD":             {"ci/cd", "continuous integration", "continuous delivery", "continuous deployment"},
+	"A11y":             {"accessibility"},
+	"TDD":              {"test-driven development", "test driven development"},
+	"Containerization": {"containers", "containerisation"},
+	"AWS":              {"amazon web services"},
+	"GCP":              {"google cloud", "google cloud platform"},
+	"React":            {"react.js", "reactjs"},
+	"Vue":              {"vue.js", "vuejs"},
+}
+
+// Well-known technical keywords that are looked for in job postings (in addition to the resume terms).
+var defaultKeywords = []string{
+	"Python", "Java", "Rust", "C++", "C#", "Ruby", "PHP", "Kotlin", "Swift", "Scala", "Elixir",
+	"SQL", "MySQL", "MongoDB", "Redis", "Kafka", "RabbitMQ", "Elasticsearch",
+	"Docker", "Terraform", "Ansible", "Linux", "Azure", "Prometheus", "Grafana",
+	"GraphQL", "gRPC", "Microservices", "Git",
+	"HTML", "CSS", "Angular", "Svelte",
+	"DNS", "HTTP", "TCP", "TLS", "SMTP",
+}
+
+type MatchOptions struct {
+	// Optional: Canonical name to aliases (ex: {"Go": ["golang"]}),
+	// merged with (and taking precedence over) DefaultSynonyms.
+	Synonyms map[string][]string
+}
+
+// Reports how well a resume matches a job posting.
+type MatchReport struct {
+	Coverage float64        `json:"coverage"` // Ratio of posting keywords found in the resume (from 0 to 1).
+	Keywords []string       `json:"keywords"` // Keywords found in the job posting.
+	Matched  []string       `json:"matched"`  // Keywords found in both the job posting and the resume.
+	Missing  []string       `json:"missing"`  // Keywords found in the job posting but not in the resume.
+	Entries  []MatchedEntry `json:"entries"`  // Work experiences and projects, most relevant first.
+}
+
+type MatchedEntry struct {
+	Section  string   `json:"section"` // Either "work_experience" or "projects".
+	Index    int      `json:"index"`   // Index in the section.
+	Title    string   `json:"title"`
+	Keywords []string `json:"keywords"` // Posting keywords mentioned in the entry.
+}
+
+// Extracts keywords from the job posting and matches them against
+// the skills, work experiences, projects and descriptions of the resume.
+func MatchJobPosting(conf *ResumeConfig, posting string, opts MatchOptions) *MatchReport {
+	m := newKeywordMatcher(opts.Synonyms)
+
+	// Register resume terms first (so that the resume spelling is used in the report).
+	for _, skill := range conf.Skills {
+		for _, tool := range skill.Tools {
+			m.add(tool.Name)
+		}
+	}
+	for _, v := range conf.WorkExperience {
+		for _, skill := range v.Skills {
+			m.add(skill)
+		}
+	}
+	for _, v := range conf.Projects {
+		for _, tech := range v.Technologies {
+			m.add(tech)
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(m.synonyms)) {
+		m.add(name)
+	}
+	for _, v := range defaultKeywords {
+		m.add(v)
+	}
+
+	// Find posting keywords and check which ones are mentioned in the resume.
+	report := &MatchReport{Keywords: []string{}, Matched: []string{}, Missing: []string{}, Entries: []MatchedEntry{}}
+	keys := m.find(tokenize(posting))
+	resumeKeys := m.find(tokenize(resumeText(conf)))
+	for _, key := range keys {
+		report.Keywords = append(report.Keywords, m.names[key])
+		if slices.Contains(resumeKeys, key) {
+			report.Matched = append(report.Matched, m.names[key])
+		} else {
+			report.Missing = append(report.Missing, m.names[key])
+		}
+	}
+	if len(keys) > 0 {
+		report.Coverage = float64(len(report.Matched)) / float64(len(keys))
+	}
+
+	// Rank work experiences and projects by number of mentioned keywords.
+	addEntry := func(section string, i int, title string, texts ...string) {
+		entry := MatchedEntry{Section: section, Index: i, Title: title, Keywords: []string{}}
+		entryKeys := m.find(tokenize(strings.Join(texts, "\n")))
+		for _, key := range keys {
+			if slices.Contains(entryKeys, key) {
+				entry.Keywords = append(entry.Keywords, m.names[key])
+			}
+		}
+		report.Entries = append(report.Entries, entry)
+	}
+	for i, v := range conf.WorkExperience {
+		title := v.Title
+		if v.Organization != "" {
+			title += " at " + v.Organization
+		}
+		addEntry("work_experience", i, title, v.Title, mdlite.ToPlainText(v.Description),
+			strings.Join(v.Highlights, "\n"), strings.Join(v.Skills, "\n"))
+	}
+	for i, v := range conf.Projects {
+		addEntry("projects", i, v.Name, v.Name, v.Role, mdlite.ToPlainText(v.Description),
+			strings.Join(v.Highlights, "\n"), strings.Join(v.Technologies, "\n"))
+	}
+	slices.SortStableFunc(report.Entries, func(a, b MatchedEntry) int {
+		return cmp.Compare(len(b.Keywords), len(a.Keywords))
+	})
+
+	return report
+}
+
+// Returns all resume text that is searched for keywords.
+func resumeText(conf *ResumeConfig) string {
+	texts := []string{mdlite.ToPlainText(conf.Description)}
+	for _, skill := range conf.Skills {
+		texts = append(texts, skill.Title)
+		texts = append(texts, toolNames(skill.Tools)...)
+	}
+	for _, v := range conf.WorkExperience {
+		texts = append(texts, v.Title, mdlite.ToPlainText(v.Description))
+		texts = append(texts, v.Highlights...)
+		texts = append(texts, v.Skills...)
+	}
+	for _, v := range conf.Projects {
+		texts = append(texts, v.Name, mdlite.ToPlainText(v.Description))
+		texts = append(texts, v.Highlights...)
+		texts = append(texts, v.Technologies...)
+	}
+	for _, v := range conf.Education {
+		texts = append(texts, v.Title)
+	}
+	now := time.Now()
+	for _, v := range conf.Certifications {
+		if conf.HideExpiredCertifications && v.IsExpired(now) {
+			continue // Not rendered in exports.
+		}
+		texts = append(texts, v.Title)
+	}
+	// Note: Each text is on its own line to avoid matching phrases across texts.
+	return strings.Join(texts, "\n")
+}
+
+// Returns the resume config file tailored for the job posting (see TailorResume), in canonical format.
+// Note: The config is only decoded (not loaded) so that referenced files (ex: PGP key)
+// are not inlined and hidden entries (ex: expired certifications) are kept,
+// only the skills, work experience and projects are rewritten.
+func TailorResumeConfig(b []byte, report *MatchReport) ([]byte, error) {
+	doc := map[string]json.RawMessage{}
+	err := json.Unmarshal(b, &doc)
+	if err != nil {
+		return nil, fmt.Errorf("decode config: %w", err)
+	}
+	conf := &ResumeConfig{}
+	err = json.Unmarshal(b, conf)
+	if err != nil {
+		return nil, fmt.Errorf("decode config: %w", err)
+	}
+	tailored := TailorResume(conf, report)
+	for k, v := range map[string]any{
+		"skills":          tailored.Skills,
+		"work_experience": tailored.WorkExperience,
+		"projects":        tailored.Projects,
+	} {
+		if doc[k] == nil {
+			continue
+		}
+		doc[k], err = json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+	}
+	b, err = json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	return FormatResumeConfig(b, FormatOptions{})
+}
+
+// Returns a copy of the resume tailored for the job posting:
+// matched skill tools are featured and listed first,
+// skills, work experience and projects are sorted by relevance.
+func TailorResume(conf *ResumeConfig, report *MatchReport) *ResumeConfig {
+	out := *conf
+	isMatched := func(name string) bool {
+		return slices.ContainsFunc(report.Matched, func(v string) bool { return strings.EqualFold(v, name) })
+	}
+
+	// Feature matched tools and sort skills by number of matched tools.
+	countMatched := func(skill Skill) (n int) {
+		for _, tool := range skill.Tools {
+			if isMatched(tool.Name) {
+				n++
+			}
+		}
+		return n
+	}
+	out.Skills = slices.Clone(conf.Skills)
+	for i := range out.Skills {
+		tools := slices.Clone(out.Skills[i].Tools)
+		for j := range tools {
+			if isMatched(tools[j].Name) {
+				tools[j].Featured = true
+			}
+		}
+		slices.SortStableFunc(tools, func(a, b Tool) int {
+			if a.Featured == b.Featured {
+				return 0
+			} else if a.Featured {
+				return -1
+			}
+			return 1
+		})
+		out.Skills[i].Tools = tools
+	}
+	slices.SortStableFunc(out.Skills, func(a, b Skill) int { return cmp.Compare(countMatched(b), countMatched(a)) })
+
+	// Sort work experience and projects by relevance.
+	out.WorkExperience = make([]WorkExperience, 0, len(conf.WorkExperience))
+	out.Projects = make([]Project, 0, len(conf.Projects))
+	for _, entry := range report.Entries {
+		switch entry.Section {
+		case "work_experience":
+			out.WorkExperience = append(out.WorkExperience, conf.WorkExperience[entry.Index])
+		case "projects":
+			out.Projects = append(out.Projects, conf.Projects[entry.Index])
+		}
+	}
+	return &out
+}
+
+// Writes the report in a human-readable format.
+func WriteMatchReport(w io.Writer, report *MatchReport) error {
+	b := &strings.Builder{}
+	fmt.Fprintf(b, "Coverage: %.0f%% (%d/%d keywords)\n", report.Coverage*100, len(report.Matched), len(report.Keywords))
+	fmt.Fprintf(b, "Matched: %s\n", joinOrNone(report.Matched))
+	fmt.Fprintf(b, "Missing: %s\n", joinOrNone(report.Missing))
+	fmt.Fprintf(b, "Most relevant entries:\n")
+	for _, v := range report.Entries {
+		if len(v.Keywords) == 0 {
+			break
+		}
+		fmt.Fprintf(b, "- %s (%d keyword(s): %s)\n", v.Title, len(v.Keywords), strings.Join(v.Keywords, ", "))
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func joinOrNone(v []string) string {
+	if len(v) == 0 {
+		return "none"
+	}
+	return strings.Join(v, ", ")
+}
+
+// Splits the text into lowercase words (keeping characters used in technology names, like "c++" or "node.js").
+// Line breaks are kept as empty tokens so that phrases don't match across lines.
+// Note: Hyphenated words are kept whole, so that short names only match whole words
+// (ex: "go-to-market" doesn't match "Go").
+func tokenize(text string) (tokens []string) {
+	for i, line := range strings.Split(strings.ToLower(text), "\n") {
+		if i > 0 {
+			tokens = append(tokens, "")
+		}
+		words := strings.FieldsFunc(line, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r) && !strings.ContainsRune("+#.-", r)
+		})
+		for _, word := range words {
+			if word = strings.Trim(word, ".-"); word != "" {
+				tokens = append(tokens, word)
+			}
+		}
+	}
+	return tokens
+}
+
+// Matches keywords (and their synonyms) in tokenized text.
+type keywordMatcher struct {
+	synonyms map[string][]string   // Canonical name to aliases.
+	aliases  map[string]string     // Normalized alias to key.
+	surfaces map[string][][]string // Key to tokenized surface forms (name and aliases).
+	names    map[string]string     // Key to display name.
+	keys     []string              // Keys in order of registration.
+}
+
+func newKeywordMatcher(synonyms map[string][]string) *keywordMatcher {
+	m := &keywordMatcher{
+		synonyms: maps.Clone(DefaultSynonyms),
+		aliases:  map[string]string{},
+		surfaces: map[string][][]string{},
+		names:    map[string]string{},
+	}
+	maps.Copy(m.synonyms, synonyms)
+	for _, name := range slices.Sorted(maps.Keys(m.synonyms)) {
+		key := strings.Join(tokenize(name), " ")
+		m.aliases[key] = key
+		for _, alias := range m.synonyms[name] {
+			m.aliases[strings.Join(tokenize(alias), " ")] = key
+		}
+	}
+	return m
+}
+
+// Registers a keyword (no-op if it, or one of its synonyms, is already registered).
+func (m *keywordMatcher) add(name string) {
+	key := strings.Join(tokenize(name), " ")
+	if canonical, ok := m.aliases[key]; ok {
+		key = canonical
+	}
+	if key == "" || m.names[key] != "" {
+		return
+	}
+	m.names[key] = name
+	m.keys = append(m.keys, key)
+	m.surfaces[key] = append(m.surfaces[key], strings.Fields(key))
+	for alias, canonical := range m.aliases {
+		if canonical == key && alias != key {
+			m.surfaces[key] = append(m.surfaces[key], strings.Fields(alias))
+		}
+	}
+}
+
+// Returns the keys of the registered keywords found in the tokens (in order of registration).
+func (m *keywordMatcher) find(tokens []string) (keys []string) {
+	for _, key := range m.keys {
+		if slices.ContainsFunc(m.surfaces[key], func(phrase []string) bool { return containsPhrase(tokens, phrase) }) {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+func containsPhrase(tokens, phrase []string) bool {
+	for i := 0; i+len(phrase) <= len(tokens); i++ {
+		if slices.Equal(tokens[i:i+len(phrase)], phrase) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,73 @@
+package nubio
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestMatchJobPosting(t *testing.T) {
+	conf := &ResumeConfig{
+		Skills: []Skill{{Title: "Backend", Tools: []Tool{{Name: "Go"}, {Name: "Node.js"}, {Name: "TypeScript"}}}},
+	}
+	tests := []struct {
+		posting string
+		want    []string
+	}{
+		{"We use golang and JS.", []string{"Go", "JavaScript"}},
+		{"Experience with Go, Node and TS.", []string{"Go", "Node.js", "TypeScript"}},
+		{"Go-to-market strategy, node-based editors and ts-free code.", nil},
+	}
+	for _, test := range tests {
+		got := MatchJobPosting(conf, test.posting, MatchOptions{}).Keywords
+		slices.Sort(got)
+		if !slices.Equal(got, test.want) {
+			t.Errorf("%q: want keywords %q, got %q", test.posting, test.want, got)
+		}
+	}
+}
+
+func TestTailorResumeConfig(t *testing.T) {
+	raw := `{
+		"name": "Jane Doe",
+		"pgp_key_path": "key.asc",
+		"hide_expired_certifications": true,
+		"work_experience": [
+			{"title": "Frontend Engineer", "organization": "A", "from": "January 2020", "to": "now", "skills": ["Svelte"]},
+			{"title": "Backend Engineer", "organization": "B", "from": "January 2018", "to": "December 2019", "skills": ["Go"]}
+		],
+		"skills": [{"title": "Backend", "tools": ["Svelte", "Go"]}],
+		"certifications": [{"title": "Expired", "issuer": "X", "date": "January 2010", "expiry": "January 2011"}]
+	}`
+	conf := &ResumeConfig{}
+	err := json.Unmarshal([]byte(raw), conf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := TailorResumeConfig([]byte(raw), MatchJobPosting(conf, "Go developer", MatchOptions{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tailored := &ResumeConfig{}
+	err = json.Unmarshal(b, tailored)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tailored.PGPKeyPath != "key.asc" || tailored.PGPKey != "" {
+		t.Errorf("want PGP key path only, got %s", b)
+	}
+	if len(tailored.Certifications) != 1 {
+		t.Errorf("want hidden certification to be kept, got %s", b)
+	}
+	if len(tailored.WorkExperience) != 2 || tailored.WorkExperience[0].Title != "Backend Engineer" {
+		t.Errorf("want relevant work experience first, got %s", b)
+	}
+	if tools := tailored.Skills[0].Tools; tools[0] != (Tool{Name: "Go", Featured: true}) {
+		t.Errorf("want matched tool featured first, got %+v", tools)
+	}
+	if !strings.Contains(string(b), `"Svelte"`) {
+		t.Errorf("want tools without details written as names, got %s", b)
+	}
+}
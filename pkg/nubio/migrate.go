@@ -0,0 +1,215 @@
+package nubio
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// Version of the resume config layout.
+// Files without "schema_version" are considered current unless they use a legacy layout.
+const CurrentSchemaVersion = 1
+
+// Describes a breaking change of the config layout (see changelog).
+type layoutMigration struct {
+	Description string
+	Detect      func(doc map[string]json.RawMessage) bool
+	Apply       func(doc map[string]json.RawMessage) (dropped []string) // Returns the legacy fields that were dropped.
+}
+
+// Migrations applied to the resume config (in order).
+var resumeMigrations = []layoutMigration{
+	{
+		Description: `rename "name_slug" to "slug" (v0.5.0)`,
+		Detect:      func(doc map[string]json.RawMessage) bool { return doc["name_slug"] != nil },
+		Apply:       func(doc map[string]json.RawMessage) []string { return renameKey(doc, "name_slug", "slug") },
+	},
+	{
+		Description: `rename "experiences" to "work_experience" (v0.4.2)`,
+		Detect:      func(doc map[string]json.RawMessage) bool { return doc["experiences"] != nil },
+		Apply:       func(doc map[string]json.RawMessage) []string { return renameKey(doc, "experiences", "work_experience") },
+	},
+	{
+		Description: `move "contact" fields to the root (v0.6.0)`,
+		Detect:      func(doc map[string]json.RawMessage) bool { return doc["contact"] != nil },
+		Apply: func(doc map[string]json.RawMessage) (dropped []string) {
+			contact := map[string]json.RawMessage{}
+			if json.Unmarshal(doc["contact"], &contact) != nil {
+				dropped = append(dropped, "contact (invalid value)")
+			}
+			delete(doc, "contact")
+			for _, k := range slices.Sorted(maps.Keys(contact)) {
+				if doc[k] == nil {
+					doc[k] = contact[k]
+				} else {
+					dropped = append(dropped, fmt.Sprintf("contact.%s (conflicts with %q)", k, k))
+				}
+			}
+			return dropped
+		},
+	},
+}
+
+// Fields that used to be at the root of the combined "config.json" file (before v0.5.0).
+var legacyRootResumeFields = []string{"custom_css", "custom_css_path", "pgp_key", "pgp_key_path"}
+
+// Holds the result of a config migration.
+type ConfigMigration struct {
+	Resume  []byte   // Migrated (and formatted) resume config.
+	Server  []byte   // Migrated server config (only for combined "config.json" files, otherwise nil).
+	Changes []string // Applied changes.
+	Dropped []string // Unknown fields and conflicting legacy fields that were dropped.
+}
+
+// Rewrites a resume config (or a legacy combined "config.json" file) to the current layout.
+// The resume path is used in the server config (only for combined "config.json" files).
+func MigrateConfig(b []byte, resumePath string) (*ConfigMigration, error) {
+	doc := map[string]json.RawMessage{}
+	err := json.Unmarshal(b, &doc)
+	if err != nil {
+		return nil, fmt.Errorf("decode config: %w", err)
+	}
+	result := &ConfigMigration{}
+
+	// Split combined "config.json" file (before v0.6.0).
+	var serverDoc map[string]json.RawMessage
+	if doc["resume"] != nil {
+		result.Changes = append(result.Changes, `split "config.json" into resume and server config (v0.6.0)`)
+		serverDoc = doc
+		doc = map[string]json.RawMessage{}
+		err = json.Unmarshal(serverDoc["resume"], &doc)
+		if err != nil {
+			return nil, fmt.Errorf("decode resume: %w", err)
+		}
+		delete(serverDoc, "resume")
+		for _, k := range legacyRootResumeFields {
+			if serverDoc[k] != nil {
+				if doc[k] == nil {
+					doc[k] = serverDoc[k]
+				} else {
+					result.Dropped = append(result.Dropped, fmt.Sprintf("%s (conflicts with \"resume.%s\")", k, k))
+				}
+				delete(serverDoc, k)
+				result.Changes = append(result.Changes, fmt.Sprintf("move %q inside resume config (v0.5.0)", k))
+			}
+		}
+	}
+
+	// Apply resume migrations.
+	for _, migration := range resumeMigrations {
+		if migration.Detect(doc) {
+			result.Dropped = append(result.Dropped, migration.Apply(doc)...)
+			result.Changes = append(result.Changes, migration.Description)
+		}
+	}
+	if version, _ := decodeSchemaVersion(doc); version != CurrentSchemaVersion {
+		doc["schema_version"] = json.RawMessage(fmt.Sprint(CurrentSchemaVersion))
+		result.Changes = append(result.Changes, fmt.Sprintf("set schema version to %d", CurrentSchemaVersion))
+	}
+
+	// Encode resume config (in canonical format).
+	for _, k := range unknownKeys(doc, ResumeConfig{}) {
+		result.Dropped = append(result.Dropped, k+" (unknown field)")
+		delete(doc, k) // Note: Formatting keeps unknown keys.
+	}
+	b, err = json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	result.Resume, err = FormatResumeConfig(b, FormatOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	// Encode server config if needed.
+	if serverDoc != nil {
+		for _, k := range unknownKeys(serverDoc, ServerConfig{}) {
+			result.Dropped = append(result.Dropped, "server: "+k+" (unknown field)")
+		}
+		b, err = json.Marshal(serverDoc)
+		if err != nil {
+			return nil, err
+		}
+		serverConf := &ServerConfig{}
+		err = json.Unmarshal(b, serverConf)
+		if err != nil {
+			return nil, fmt.Errorf("decode server config: %w", err)
+		}
+		serverConf.ResumePath = resumePath
+		result.Server, err = json.MarshalIndent(serverConf, "", "    ")
+		if err != nil {
+			return nil, err
+		}
+		result.Server = append(result.Server, '\n')
+	}
+	return result, nil
+}
+
+// Returns an error if the resume config uses a legacy layout or an unsupported schema version.
+func checkSchema(b []byte, path string) error {
+	doc := map[string]json.RawMessage{}
+	if json.Unmarshal(b, &doc) != nil {
+		return nil // Note: Decoding errors are reported by the caller.
+	}
+	version, err := decodeSchemaVersion(doc)
+	if err != nil {
+		return err
+	}
+	if version > CurrentSchemaVersion {
+		return fmt.Errorf("unsupported schema version %d (max %d), please upgrade nubio", version, CurrentSchemaVersion)
+	}
+	if doc["resume"] != nil {
+		return fmt.Errorf(`outdated config layout ("resume" field), run "nubio migrate %s"`, path)
+	}
+	for _, migration := range resumeMigrations {
+		if migration.Detect(doc) {
+			return fmt.Errorf("outdated config layout (%s), run \"nubio migrate %s\"", migration.Description, path)
+		}
+	}
+	return nil
+}
+
+func decodeSchemaVersion(doc map[string]json.RawMessage) (version int, err error) {
+	if doc["schema_version"] == nil {
+		return 0, nil
+	}
+	err = json.Unmarshal(doc["schema_version"], &version)
+	if err != nil {
+		return 0, fmt.Errorf("invalid schema version: %w", err)
+	}
+	return version, nil
+}
+
+// Renames the key, the legacy value is dropped (and returned) if the new key is already set.
+func renameKey(doc map[string]json.RawMessage, from, to string) (dropped []string) {
+	if doc[to] == nil {
+		doc[to] = doc[from]
+	} else {
+		dropped = append(dropped, fmt.Sprintf("%s (conflicts with %q)", from, to))
+	}
+	delete(doc, from)
+	return dropped
+}
+
+// Returns the keys that don't match any JSON field of the given struct (sorted).
+func unknownKeys(doc map[string]json.RawMessage, v any) (keys []string) {
+	typ := reflect.TypeOf(v)
+	for k := range doc {
+		known := false
+		for i := 0; i < typ.NumField(); i++ {
+			name, _, _ := strings.Cut(typ.Field(i).Tag.Get("json"), ",")
+			if name == k {
+				known = true
+				break
+			}
+		}
+		if !known {
+			keys = append(keys, k)
+		}
+	}
+	slices.Sort(keys)
+	return keys
+}
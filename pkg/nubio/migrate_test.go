@@ -0,0 +1,47 @@
+package nubio
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestMigrateConfig(t *testing.T) {
+	raw := `{
+		"resume": {
+			"name": "Jane Doe",
+			"name_slug": "jane",
+			"experiences": [{"title": "Legacy"}],
+			"work_experience": [{"title": "Current"}],
+			"contact": {"email_address": "legacy@example.com"},
+			"email_address": "jane@example.com",
+			"custom_css": "body {}",
+			"unknown": true
+		},
+		"custom_css": "legacy {}",
+		"address": ":8080"
+	}`
+	result, err := MigrateConfig([]byte(raw), "resume.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantDropped := []string{
+		`custom_css (conflicts with "resume.custom_css")`,
+		`experiences (conflicts with "work_experience")`,
+		`contact.email_address (conflicts with "email_address")`,
+		`unknown (unknown field)`,
+	}
+	if !slices.Equal(result.Dropped, wantDropped) {
+		t.Errorf("want dropped fields %q, got %q", wantDropped, result.Dropped)
+	}
+	err = checkSchema(result.Resume, "resume.json")
+	if err != nil {
+		t.Errorf("migrated config: %s", err)
+	}
+	conf, err := loadResumeConfig(result.Resume, "resume.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if conf.Slug != "jane" || conf.WorkExperience[0].Title != "Current" || conf.EmailAddress != "jane@example.com" || conf.CustomCSS != "body {}" {
+		t.Errorf("unexpected migrated config: %s", result.Resume)
+	}
+}
@@ -1,25 +1,114 @@
 package nubio
 
 import (
+	"bytes"
 	_ "embed"
 	"fmt"
 	"io"
+	"slices"
+	"strconv"
 	"strings"
-	"time"
 
+	"github.com/ejuju/nubio/pkg/mdlite"
 	"github.com/go-pdf/fpdf"
 )
 
+// Configures the layout and typography of the PDF export.
+// Zero values (and a missing margin) are replaced by the default values listed below.
+type PDFOptions struct {
+	Theme       string   `json:"theme"`        // Optional: "single-column" (default) or "two-column".
+	PageSize    string   `json:"page_size"`    // Optional: "A4" (default), "Letter" or "Legal" (case-insensitive).
+	Margin      *float64 `json:"margin"`       // Optional: Page margins in points (default: 50, can be 0).
+	FontSize    float64  `json:"font_size"`    // Optional: Base font size in points (default: 10).
+	LineHeight  float64  `json:"line_height"`  // Optional: Line height relative to the font size (default: 1.6).
+	AccentColor string   `json:"accent_color"` // Optional: Color of the name, headings and entry titles (default: "#0a0a0a").
+	TextColor   string   `json:"text_color"`   // Optional: Color of regular text (default: "#323232").
+	MutedColor  string   `json:"muted_color"`  // Optional: Color of labels and page numbers (default: "#969696").
+}
+
+// Supported PDF page sizes (as named by fpdf).
+// Note: Page sizes are case-insensitive (like in fpdf).
+var pdfPageSizes = []string{"A4", "Letter", "Legal"}
+
+// Supported PDF themes (layouts).
 const (
-	marginSide            = 50
-	fontSize              = 10
-	lineHeight            = fontSize + 6 // Only for regular lines.
-	fontSizeHeading       = 15
-	fontSizeTitle         = 20
-	colLeftSize           = 60
-	a4WidthPt, a4HeightPt = 595.28, 842.89
+	PDFThemeSingleColumn = "single-column"
+	PDFThemeTwoColumn    = "two-column" // Sidebar with contact details, links, skills and languages.
 )
 
+var pdfThemes = []string{PDFThemeSingleColumn, PDFThemeTwoColumn}
+
+func (o PDFOptions) withDefaults() PDFOptions {
+	if o.Theme == "" {
+		o.Theme = PDFThemeSingleColumn
+	}
+	if o.PageSize == "" {
+		o.PageSize = "A4"
+	}
+	if o.Margin == nil {
+		margin := 50.0
+		o.Margin = &margin
+	}
+	if o.FontSize == 0 {
+		o.FontSize = 10
+	}
+	if o.LineHeight == 0 {
+		o.LineHeight = 1.6
+	}
+	if o.AccentColor == "" {
+		o.AccentColor = "#0a0a0a"
+	}
+	if o.TextColor == "" {
+		o.TextColor = "#323232"
+	}
+	if o.MutedColor == "" {
+		o.MutedColor = "#969696"
+	}
+	return o
+}
+
+func (o *PDFOptions) Check() (errs []error) {
+	if o.Theme != "" && !slices.Contains(pdfThemes, o.Theme) {
+		errs = append(errs, fmt.Errorf("invalid theme %q (must be one of: %s)", o.Theme, strings.Join(pdfThemes, ", ")))
+	}
+	if o.PageSize != "" && !slices.ContainsFunc(pdfPageSizes, func(v string) bool { return strings.EqualFold(v, o.PageSize) }) {
+		errs = append(errs, fmt.Errorf("invalid page size %q (must be one of: %s)", o.PageSize, strings.Join(pdfPageSizes, ", ")))
+	}
+	if o.Margin != nil && (*o.Margin < 0 || *o.Margin > 200) {
+		errs = append(errs, fmt.Errorf("invalid margin: %v (must be between 0 and 200)", *o.Margin))
+	}
+	if o.FontSize != 0 && (o.FontSize < 6 || o.FontSize > 20) {
+		errs = append(errs, fmt.Errorf("invalid font size: %v (must be between 6 and 20)", o.FontSize))
+	}
+	if o.LineHeight != 0 && (o.LineHeight < 1 || o.LineHeight > 3) {
+		errs = append(errs, fmt.Errorf("invalid line height: %v (must be between 1 and 3)", o.LineHeight))
+	}
+	for _, v := range [][2]string{{"accent", o.AccentColor}, {"text", o.TextColor}, {"muted", o.MutedColor}} {
+		if _, err := parseHexColor(v[1]); v[1] != "" && err != nil {
+			errs = append(errs, fmt.Errorf("invalid %s color: %w", v[0], err))
+		}
+	}
+	return errs
+}
+
+type rgb struct{ r, g, b int }
+
+// Parses a hex color (ex: "#0a0a0a" or "#333").
+func parseHexColor(v string) (c rgb, err error) {
+	hex, ok := strings.CutPrefix(v, "#")
+	if len(hex) == 3 {
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	}
+	if !ok || len(hex) != 6 {
+		return c, fmt.Errorf("%q is not a hex color (ex: \"#0a0a0a\")", v)
+	}
+	n, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return c, fmt.Errorf("%q is not a hex color (ex: \"#0a0a0a\")", v)
+	}
+	return rgb{int(n >> 16), int(n >> 8 & 0xff), int(n & 0xff)}, nil
+}
+
 var (
 	//go:embed NotoSans-Regular.ttf
 	notoRegularTTF []byte
@@ -28,188 +117,677 @@ var (
 	notoBoldTTF []byte
 )
 
-func ExportPDF(w io.Writer, conf *ResumeConfig) error {
-	pdf := fpdf.New("P", "pt", "A4", "")
-	pdf.SetCreationDate(time.Now())
+// Holds the PDF document and the sizes and colors derived from the PDF options.
+type pdfDoc struct {
+	*fpdf.Fpdf
+	margin                float64
+	fontSize, lineHeight  float64 // Note: The line height is only used for regular lines.
+	fontSizeHeading       float64
+	fontSizeTitle         float64
+	colLeftSize           float64 // Width of labels (ex: "Duration").
+	accent, text, muted   rgb
+	pageWidth, pageHeight float64
+	opts                  PDFOptions
+	fonts                 *pdfFonts // Optional: Custom fonts (see pdfDoc.selectFont).
+	scratch               *pdfDoc   // Used to measure blocks before drawing them (see pdfDoc.measure).
+}
+
+// Creates a new document with the embedded (and custom) fonts and the page number footer.
+// Note: Options must be checked beforehand, invalid colors are replaced by black.
+func newPDFDoc(opts PDFOptions, fonts *Fonts) *pdfDoc {
+	opts = opts.withDefaults()
+	pdf := &pdfDoc{
+		Fpdf:            fpdf.New("P", "pt", opts.PageSize, ""),
+		margin:          *opts.Margin,
+		fontSize:        opts.FontSize,
+		lineHeight:      opts.FontSize * opts.LineHeight,
+		fontSizeHeading: opts.FontSize * 1.5,
+		fontSizeTitle:   opts.FontSize * 2,
+		colLeftSize:     opts.FontSize * 6,
+		opts:            opts,
+	}
+	pdf.accent, _ = parseHexColor(opts.AccentColor)
+	pdf.text, _ = parseHexColor(opts.TextColor)
+	pdf.muted, _ = parseHexColor(opts.MutedColor)
+	pdf.pageWidth, pdf.pageHeight = pdf.GetPageSize()
+
+	pdf.SetCatalogSort(true) // Note: Fonts are written in random (map) order otherwise.
 	pdf.SetLang("en")
-	pdf.SetAuthor(conf.Name, true)
-	pdf.SetTitle("Curriculum Vitae - "+conf.Name, true)
 
 	// Use custom font because standard fonts use cp1252 encoding.
-	fontFamily := "sans-serif"
-	pdf.AddUTF8FontFromBytes(fontFamily, "", notoRegularTTF)
-	pdf.AddUTF8FontFromBytes(fontFamily, "B", notoBoldTTF)
-	pdf.SetFont(fontFamily, "", fontSize)
+	pdf.AddUTF8FontFromBytes(pdfFontFamily, "", notoRegularTTF)
+	pdf.AddUTF8FontFromBytes(pdfFontFamily, "B", notoBoldTTF)
+	pdf.SetFont(pdfFontFamily, "", pdf.fontSize)
+	pdf.addCustomFonts(fonts)
 
-	pdf.SetTopMargin(50)
-	pdf.SetLeftMargin(marginSide)
-	pdf.SetRightMargin(marginSide)
-	pdf.SetTextColor(10, 10, 10)
+	pdf.SetMargins(pdf.margin, pdf.margin, pdf.margin)
+	pdf.SetAutoPageBreak(true, pdf.margin) // Note: The footer is written in the bottom margin.
+	pdf.SetAcceptPageBreakFunc(pdf.acceptPageBreak)
+	pdf.setTextColor(pdf.accent)
 
 	// Define footer.
 	pdf.AliasNbPages("{max_page}")
 	pdf.SetFooterFuncLpi(func(isLastPage bool) {
 		pdf.SetFontStyle("")
-		pdf.SetFontSize(fontSize)
-		pdf.SetTextColor(50, 50, 50)
+		pdf.SetFontSize(pdf.fontSize)
+		pdf.setTextColor(pdf.muted)
 		txt := fmt.Sprintf("Page %d/{max_page}", pdf.PageCount())
-		pdf.Text(marginSide, a4HeightPt-3*fontSize, txt)
+		pdf.Text(pdf.margin, pdf.pageHeight-pdf.margin/2-pdf.fontSize/2, txt)
 	})
+	return pdf
+}
 
-	// Append title (name).
+func (pdf *pdfDoc) setTextColor(c rgb) { pdf.SetTextColor(c.r, c.g, c.b) }
+func (pdf *pdfDoc) setDrawColor(c rgb) { pdf.SetDrawColor(c.r, c.g, c.b) }
+func (pdf *pdfDoc) setFillColor(c rgb) { pdf.SetFillColor(c.r, c.g, c.b) }
+
+// Returns the width between the current left and right margins.
+func (pdf *pdfDoc) columnWidth() float64 {
+	left, _, right, _ := pdf.GetMargins()
+	return pdf.pageWidth - left - right
+}
+
+// Moves to the top of the next page, the page is only added if the current page is the last one.
+// Note: Columns are written one after the other, so the next page may already exist.
+func (pdf *pdfDoc) nextPage() {
+	if pdf.PageNo() >= pdf.PageCount() {
+		pdf.AddPage()
+		return
+	}
+	_, top, _, _ := pdf.GetMargins()
+	pdf.SetPage(pdf.PageNo() + 1)
+	pdf.SetY(top)
+	size, _ := pdf.GetFontSize()
+	pdf.SetFontSize(size) // Note: The font must be set again in the page content.
+}
+
+// Used for automatic page breaks (see pdfDoc.nextPage).
+func (pdf *pdfDoc) acceptPageBreak() bool {
+	if auto, _ := pdf.GetAutoPageBreak(); !auto {
+		return false
+	}
+	if pdf.PageNo() >= pdf.PageCount() {
+		return true // Let fpdf add the page.
+	}
+	pdf.nextPage()
+	return false
+}
+
+func ExportPDF(w io.Writer, conf *ResumeConfig, opts ExportOptions) error {
+	pdf := newPDFDoc(conf.PDF, &conf.Fonts)
+	pdf.SetCreationDate(opts.time())
+	pdf.SetModificationDate(opts.time()) // Note: fpdf uses the current time if no date is set.
+	pdf.SetAuthor(conf.Name, true)
+	pdf.SetTitle("Curriculum Vitae - "+conf.Name, true)
+	switch pdf.opts.Theme {
+	case PDFThemeTwoColumn:
+		writeTwoColumnPDF(pdf, conf)
+	default:
+		writeSingleColumnPDF(pdf, conf)
+	}
+
+	// Write whole PDF.
+	return pdf.Output(w)
+}
+
+func writeSingleColumnPDF(pdf *pdfDoc, conf *ResumeConfig) {
+	// Append avatar and title (name).
 	pdf.AddPage()
-	pdf.SetFontSize(fontSizeTitle)
+	if len(conf.Avatar) > 0 {
+		writeAvatar(pdf, conf)
+	}
+	pdf.SetFontSize(pdf.fontSizeTitle)
 	pdf.SetFontStyle("B")
-	pdf.MultiCell(0, fontSizeTitle, conf.Name, "", "C", false)
-	pdf.SetFontSize(fontSize)
+	pdf.MultiCell(0, pdf.fontSizeTitle, conf.Name, "", "C", false)
+	pdf.SetFontSize(pdf.fontSize)
 
 	// Append short description.
-	pdf.Ln(fontSize)
+	pdf.Ln(pdf.fontSize)
 	pdf.SetFontStyle("")
-	pdf.MultiCell(0, fontSizeTitle, conf.Description, "", "C", false)
+	pdf.MultiCell(0, pdf.fontSizeTitle, mdlite.ToPlainText(conf.Description), "", "C", false)
 
 	// Append horizontal line below title.
-	pdf.Ln(fontSizeTitle)
-	pdf.Rect(marginSide, pdf.GetY(), a4WidthPt-2*marginSide, 0.5, "F")
+	pdf.Ln(pdf.fontSizeTitle)
+	pdf.setFillColor(pdf.accent)
+	pdf.Rect(pdf.margin, pdf.GetY(), pdf.pageWidth-2*pdf.margin, 0.5, "F")
+
+	// Append sections.
+	// Note: Interests and hobbies are only shown on the website.
+	sections := []pdfSection{
+		pdfWorkExperienceSection(conf),
+		pdfProjectsSection(conf),
+		pdfSkillsSection(conf),
+		pdfLanguagesSection(conf),
+		pdfEducationSection(conf),
+		pdfCertificationsSection(conf),
+		pdfPublicationsSection(conf),
+		pdfTalksSection(conf),
+		pdfAwardsSection(conf),
+	}
+	sections = append(sections, pdfCustomSections(conf)...)
+	sections = append(sections, pdfLinksSection(conf), pdfContactSection(conf))
+	for _, section := range sections {
+		writeSection(pdf, section)
+	}
+}
+
+// A section of the PDF export.
+type pdfSection struct {
+	Heading string
+	Spacing float64         // Space before each entry.
+	Entries []func(*pdfDoc) // Each entry is kept on a single page if possible.
+}
 
-	// Append work experiences.
-	pdf.Ln(24)
-	writeHeading(pdf, "Work Experience")
+// Writes the section heading and entries (sections without entries are skipped).
+// Note: The heading is kept on the same page as the first entry (to avoid orphan headings).
+func writeSection(pdf *pdfDoc, section pdfSection) {
+	for i, entry := range section.Entries {
+		if i > 0 {
+			pdf.writeBlock(section.Spacing, entry)
+			continue
+		}
+		pdf.writeBlock(24, func(pdf *pdfDoc) {
+			writeHeading(pdf, section.Heading)
+			pdf.Ln(section.Spacing)
+			entry(pdf)
+		})
+	}
+}
+
+// Writes the block (after the given space) on the current page if it fits,
+// otherwise on the next page (without the leading space).
+// Note: Blocks taller than a page are split by automatic page breaks.
+func (pdf *pdfDoc) writeBlock(space float64, block func(*pdfDoc)) {
+	_, top, _, bottom := pdf.GetMargins()
+	h := pdf.measure(block)
+	switch {
+	case pdf.GetY() <= top:
+		// Skip leading space at the top of a page.
+	case pdf.GetY()+space+h > pdf.pageHeight-bottom && h <= pdf.pageHeight-top-bottom:
+		pdf.nextPage()
+	default:
+		pdf.Ln(space)
+	}
+	block(pdf)
+}
+
+// Returns the height of the block, measured by drawing it on a scratch document
+// (with the same options and margins, but without automatic page breaks).
+func (pdf *pdfDoc) measure(block func(*pdfDoc)) float64 {
+	if pdf.scratch == nil {
+		var fonts *Fonts
+		if pdf.fonts != nil {
+			fonts = pdf.fonts.conf
+		}
+		pdf.scratch = newPDFDoc(pdf.opts, fonts)
+		pdf.scratch.SetAutoPageBreak(false, 0)
+	}
+	left, top, right, _ := pdf.GetMargins()
+	pdf.scratch.SetMargins(left, top, right)
+	pdf.scratch.AddPage()
+	block(pdf.scratch)
+	return pdf.scratch.GetY() - top
+}
+
+func pdfWorkExperienceSection(conf *ResumeConfig) pdfSection {
+	section := pdfSection{Heading: "Work Experience", Spacing: 16}
 	for _, v := range conf.WorkExperience {
-		orgTxt := ""
-		if v.Organization != "" {
-			orgTxt = " at " + v.Organization
-		}
-		pdf.Ln(16)
-		pdf.SetFontSize(fontSize)
-		pdf.SetFontStyle("B")
-		pdf.SetTextColor(30, 30, 30)
-		pdf.MultiCell(0, fontSize, v.Title+orgTxt, "", "", false)
-		pdf.Ln(6)
-		pdf.SetFontStyle("")
-		pdf.SetTextColor(50, 50, 50)
-		pdf.MultiCell(0, fontSize, v.Description, "", "", false)
-		pdf.Ln(6)
+		section.Entries = append(section.Entries, func(pdf *pdfDoc) {
+			title := v.Title
+			if v.Organization != "" {
+				title += " at " + v.Organization
+			}
+			writeEntryTitle(pdf, title)
+			writeRichText(pdf, v.Description)
+			pdf.Ln(6)
+			for _, h := range limitHighlights(v.Highlights, conf.MaxHighlights) {
+				writeBullet(pdf, h)
+			}
+			writeKV(pdf, "Duration", v.From+" to "+v.To)
+			writeKV(pdf, "Location", v.Location)
+			writeKV(pdf, "Skills", strings.Join(v.Skills, ", "))
+		})
+	}
+	return section
+}
 
-		writeKV(pdf, "Duration", v.From+" to "+v.To)
-		writeKV(pdf, "Location", v.Location)
-		writeKV(pdf, "Skills", strings.Join(v.Skills, ", "))
+func pdfProjectsSection(conf *ResumeConfig) pdfSection {
+	section := pdfSection{Heading: "Projects", Spacing: 16}
+	for _, v := range conf.Projects {
+		section.Entries = append(section.Entries, func(pdf *pdfDoc) {
+			title := v.Name
+			if v.Role != "" {
+				title += " (" + v.Role + ")"
+			}
+			writeEntryTitle(pdf, title)
+			writeRichText(pdf, v.Description)
+			pdf.Ln(6)
+			for _, h := range limitHighlights(v.Highlights, conf.MaxHighlights) {
+				writeBullet(pdf, h)
+			}
+			if v.From != "" {
+				writeKV(pdf, "Duration", formatOptionalDuration(v.From, v.To))
+			}
+			if v.URL != "" {
+				writeKVLink(pdf, "Website", displayURL(v.URL), v.URL)
+			}
+			if v.Repository != "" {
+				writeKVLink(pdf, "Source", displayURL(v.Repository), v.Repository)
+			}
+			if len(v.Technologies) > 0 {
+				writeKV(pdf, "Skills", strings.Join(v.Technologies, ", "))
+			}
+		})
 	}
+	return section
+}
 
-	// Append skills.
-	pdf.AddPage()
-	writeHeading(pdf, "Skills")
+func pdfSkillsSection(conf *ResumeConfig) pdfSection {
+	section := pdfSection{Heading: "Skills", Spacing: 16}
 	for _, v := range conf.Skills {
-		pdf.Ln(16)
-		pdf.SetFontSize(fontSize)
-		pdf.SetFontStyle("B")
-		pdf.SetTextColor(10, 10, 10)
-		pdf.MultiCell(0, fontSize, v.Title, "", "", false)
-		pdf.Ln(4)
-		pdf.SetFontStyle("")
-		pdf.SetTextColor(80, 80, 80)
-		pdf.MultiCell(0, lineHeight, strings.Join(v.Tools, ", "), "", "", false)
+		section.Entries = append(section.Entries, func(pdf *pdfDoc) {
+			pdf.SetFontSize(pdf.fontSize)
+			pdf.SetFontStyle("B")
+			pdf.setTextColor(pdf.accent)
+			pdf.MultiCell(0, pdf.fontSize, v.Title, "", "", false)
+			pdf.Ln(4)
+			if hasToolDetails(v.Tools) {
+				writeSkillMatrix(pdf, v.Tools)
+				return
+			}
+			pdf.SetFontStyle("")
+			pdf.setTextColor(pdf.text)
+			pdf.MultiCell(0, pdf.lineHeight, strings.Join(toolNames(v.Tools), ", "), "", "", false)
+		})
 	}
+	return section
+}
 
-	// Append languages.
-	pdf.Ln(24)
-	writeHeading(pdf, "Languages")
-	pdf.Ln(16)
-	for _, v := range conf.Languages {
-		writeKV(pdf, v.Label, v.Proficiency)
+// Note: Languages are written as a single entry.
+func pdfLanguagesSection(conf *ResumeConfig) pdfSection {
+	section := pdfSection{Heading: "Languages", Spacing: 16}
+	if len(conf.Languages) > 0 {
+		section.Entries = append(section.Entries, func(pdf *pdfDoc) {
+			for _, v := range conf.Languages {
+				writeKV(pdf, v.Label, v.Proficiency)
+			}
+		})
 	}
+	return section
+}
 
-	// Append education.
-	pdf.Ln(24)
-	writeHeading(pdf, "Education")
-	pdf.Ln(8)
+func pdfEducationSection(conf *ResumeConfig) pdfSection {
+	section := pdfSection{Heading: "Education", Spacing: 12}
 	for _, v := range conf.Education {
-		pdf.Ln(8)
-		pdf.SetFontSize(fontSize)
-		pdf.SetFontStyle("B")
-		pdf.SetTextColor(30, 30, 30)
-		pdf.MultiCell(0, fontSize, v.Title, "", "", false)
-		pdf.Ln(6)
-		writeKV(pdf, "School", v.Organization)
-		writeKV(pdf, "Duration", v.From+" to "+v.To)
+		section.Entries = append(section.Entries, func(pdf *pdfDoc) {
+			writeEntryTitle(pdf, v.Title)
+			writeKV(pdf, "School", v.Organization)
+			writeKV(pdf, "Duration", v.From+" to "+v.To)
+			for _, h := range limitHighlights(v.Highlights, conf.MaxHighlights) {
+				writeBullet(pdf, h)
+			}
+		})
 	}
+	return section
+}
 
-	// Append links.
-	pdf.AddPage()
-	pdf.Ln(24)
-	writeHeading(pdf, "Links")
-	pdf.Ln(8)
-	writeLink(pdf, Link{Label: "Resume", URL: conf.Domain})
-	for _, v := range conf.Links {
-		writeLink(pdf, v)
-	}
-
-	// Append interests.
-	// pdf.Ln(24)
-	// writeHeading(pdf, "Interests")
-	// pdf.Ln(8)
-	// for _, v := range p.Interests {
-	// 	pdf.Ln(6)
-	// 	pdf.SetFontSize(fontSize)
-	// 	pdf.SetFontStyle("")
-	// 	pdf.SetTextColor(50, 50, 50)
-	// 	pdf.MultiCell(0, fontSize, "- "+v, "", "", false)
-	// }
-
-	// Append hobbies.
-	// pdf.Ln(24)
-	// writeHeading(pdf, "Hobbies")
-	// pdf.Ln(8)
-	// for _, v := range p.Hobbies {
-	// 	pdf.Ln(6)
-	// 	pdf.SetFontSize(fontSize)
-	// 	pdf.SetFontStyle("")
-	// 	pdf.SetTextColor(50, 50, 50)
-	// 	pdf.MultiCell(0, fontSize, "- "+v, "", "", false)
-	// }
-
-	// Append contact.
-	pdf.Ln(24)
-	writeHeading(pdf, "Contact")
-	addContactLink(pdf, "Email address", conf.EmailAddress, "mailto:"+conf.EmailAddress)
+func pdfCertificationsSection(conf *ResumeConfig) pdfSection {
+	section := pdfSection{Heading: "Certifications", Spacing: 12}
+	for _, v := range conf.Certifications {
+		section.Entries = append(section.Entries, func(pdf *pdfDoc) {
+			writeEntryTitle(pdf, v.Title)
+			writeKV(pdf, "Issuer", v.Issuer)
+			writeKV(pdf, "Date", v.Date)
+			if v.Expiry != "" {
+				writeKV(pdf, "Expiry", v.Expiry)
+			}
+			if v.CredentialURL != "" {
+				writeKVLink(pdf, "Credential", displayURL(v.CredentialURL), v.CredentialURL)
+			}
+		})
+	}
+	return section
+}
 
-	// Write whole PDF.
-	return pdf.Output(w)
+func pdfPublicationsSection(conf *ResumeConfig) pdfSection {
+	section := pdfSection{Heading: "Publications", Spacing: 12}
+	for _, v := range conf.Publications {
+		section.Entries = append(section.Entries, func(pdf *pdfDoc) {
+			writeEntryTitle(pdf, v.Title)
+			writeEntryDescription(pdf, v.Description)
+			writeKV(pdf, "Publisher", v.Publisher)
+			writeKV(pdf, "Date", v.Date)
+			if len(v.Authors) > 0 {
+				writeKV(pdf, "Authors", strings.Join(v.Authors, ", "))
+			}
+			if v.URL != "" {
+				writeKVLink(pdf, "Link", displayURL(v.URL), v.URL)
+			}
+		})
+	}
+	return section
 }
 
-func writeHeading(pdf fpdf.Pdf, heading string) {
+func pdfTalksSection(conf *ResumeConfig) pdfSection {
+	section := pdfSection{Heading: "Talks", Spacing: 12}
+	for _, v := range conf.Talks {
+		section.Entries = append(section.Entries, func(pdf *pdfDoc) {
+			writeEntryTitle(pdf, v.Title)
+			writeEntryDescription(pdf, v.Description)
+			writeKV(pdf, "Event", v.Event)
+			if v.Location != "" {
+				writeKV(pdf, "Location", v.Location)
+			}
+			writeKV(pdf, "Date", v.Date)
+			if v.URL != "" {
+				writeKVLink(pdf, "Link", displayURL(v.URL), v.URL)
+			}
+		})
+	}
+	return section
+}
+
+func pdfAwardsSection(conf *ResumeConfig) pdfSection {
+	section := pdfSection{Heading: "Awards", Spacing: 12}
+	for _, v := range conf.Awards {
+		section.Entries = append(section.Entries, func(pdf *pdfDoc) {
+			writeEntryTitle(pdf, v.Title)
+			writeEntryDescription(pdf, v.Description)
+			writeKV(pdf, "Issuer", v.Issuer)
+			writeKV(pdf, "Date", v.Date)
+		})
+	}
+	return section
+}
+
+func pdfCustomSections(conf *ResumeConfig) (sections []pdfSection) {
+	for _, custom := range conf.CustomSections {
+		section := pdfSection{Heading: custom.Heading, Spacing: 12}
+		for _, v := range custom.Entries {
+			section.Entries = append(section.Entries, func(pdf *pdfDoc) {
+				writeEntryTitle(pdf, v.Title)
+				writeEntryDescription(pdf, v.Subtitle)
+				if v.Body != "" {
+					writeRichText(pdf, v.Body)
+					pdf.Ln(6)
+				}
+				if v.From != "" {
+					writeKV(pdf, "Duration", formatOptionalDuration(v.From, v.To))
+				}
+				for _, link := range v.Links {
+					writeKVLink(pdf, link.Label, displayURL(link.URL), link.URL)
+				}
+				if len(v.Tags) > 0 {
+					writeKV(pdf, "Tags", strings.Join(v.Tags, ", "))
+				}
+			})
+		}
+		sections = append(sections, section)
+	}
+	return sections
+}
+
+func pdfLinksSection(conf *ResumeConfig) pdfSection {
+	section := pdfSection{Heading: "Links", Spacing: 12}
+	for _, v := range append([]Link{{Label: "Resume", URL: "https://" + conf.Domain}}, conf.Links...) {
+		section.Entries = append(section.Entries, func(pdf *pdfDoc) { writeLink(pdf, v) })
+	}
+	return section
+}
+
+// Note: Contact details are written as a single entry.
+func pdfContactSection(conf *ResumeConfig) pdfSection {
+	write := func(pdf *pdfDoc) {
+		addContactLink(pdf, "Email address", conf.EmailAddress, "mailto:"+conf.EmailAddress)
+		contact := conf.ContactDetails
+		for _, v := range contact.Phones {
+			label := "Phone"
+			if v.Label != "" {
+				label = v.Label
+			}
+			pdf.Ln(8)
+			addContactLink(pdf, label, v.Number, "tel:"+v.Number)
+		}
+		pdf.Ln(12)
+		if loc := contact.Location.String(); loc != "" {
+			writeKV(pdf, "Location", loc)
+		}
+		if contact.TimeZone.Value != "" {
+			writeKV(pdf, "Time zone", contact.TimeZone.Value)
+		}
+		if contact.Availability.Value != "" {
+			writeKV(pdf, "Availability", contact.Availability.Value)
+		}
+		if contact.PreferredMethod.Value != "" {
+			writeKV(pdf, "Preferred", contact.PreferredMethod.Value)
+		}
+	}
+	return pdfSection{Heading: "Contact", Spacing: 8, Entries: []func(*pdfDoc){write}}
+}
+
+func writeAvatar(pdf *pdfDoc, conf *ResumeConfig) {
+	const size = 72.0
+	opts := fpdf.ImageOptions{ImageType: conf.AvatarFormat}
+	info := pdf.RegisterImageOptionsReader("avatar", opts, bytes.NewReader(conf.Avatar))
+	if info == nil {
+		return // Error is reported on output.
+	}
+	w, h := size, size*info.Height()/info.Width()
+	if h > size {
+		w, h = size*info.Width()/info.Height(), size
+	}
+	left, _, _, _ := pdf.GetMargins()
+	y := pdf.GetY()
+	pdf.ImageOptions("avatar", left+(pdf.columnWidth()-w)/2, y, w, h, false, opts, 0, "")
+	pdf.SetY(y + h + 16)
+}
+
+// Writes the tools as a compact matrix (two tools per row, or one in narrow columns),
+// with the name (in bold if featured), level indicator and years of experience.
+func writeSkillMatrix(pdf *pdfDoc, tools []Tool) {
+	const (
+		levelWidth = 48
+		yearsWidth = 40
+		dotRadius  = 2.5
+		dotSpacing = 8
+	)
+	left, _, _, _ := pdf.GetMargins()
+	cols := 2
+	if pdf.columnWidth() < 2*(levelWidth+yearsWidth+pdf.colLeftSize) {
+		cols = 1
+	}
+	colWidth := pdf.columnWidth() / float64(cols)
+	nameWidth := colWidth - levelWidth - yearsWidth
+
+	pdf.SetFontSize(pdf.fontSize)
+	pdf.SetLineWidth(0.5)
+	pdf.setDrawColor(pdf.accent)
+	pdf.setFillColor(pdf.accent)
+	for i, v := range tools {
+		if i > 0 && i%cols == 0 {
+			pdf.Ln(pdf.lineHeight)
+		}
+		x := left + float64(i%cols)*colWidth
+		pdf.SetX(x)
+
+		style := ""
+		if v.Featured {
+			style = "B"
+		}
+		pdf.SetFontStyle(style)
+		pdf.setTextColor(pdf.text)
+		pdf.CellFormat(nameWidth, pdf.lineHeight, v.Name, "", 0, "", false, 0, "") // Note: May trigger a page break.
+
+		if v.Level != 0 {
+			y := pdf.GetY() + pdf.lineHeight/2
+			for j, reached := range v.Level.Steps() {
+				style := "D"
+				if reached {
+					style = "FD"
+				}
+				pdf.Circle(x+nameWidth+dotRadius+float64(j)*dotSpacing, y, dotRadius, style)
+			}
+		}
+		if v.Years > 0 {
+			pdf.SetX(x + nameWidth + levelWidth)
+			pdf.SetFontStyle("")
+			pdf.setTextColor(pdf.muted)
+			pdf.CellFormat(yearsWidth, pdf.lineHeight, formatYears(v.Years), "", 0, "", false, 0, "")
+		}
+	}
+	pdf.Ln(pdf.lineHeight)
+}
+
+func formatYears(years int) string {
+	if years == 1 {
+		return "1 year"
+	}
+	return fmt.Sprintf("%d years", years)
+}
+
+func formatOptionalDuration(from, to string) string {
+	if to == "" {
+		return from
+	}
+	return from + " to " + to
+}
+
+func writeHeading(pdf *pdfDoc, heading string) {
 	pdf.Bookmark(heading, 0, -1)
-	pdf.SetFontSize(fontSizeHeading)
+	pdf.SetFontSize(pdf.fontSizeHeading)
+	pdf.SetFontStyle("B")
+	pdf.setTextColor(pdf.accent)
+	pdf.MultiCell(0, pdf.fontSizeHeading, heading, "", "", false)
+}
+
+func writeEntryTitle(pdf *pdfDoc, title string) {
+	pdf.SetFontSize(pdf.fontSize)
 	pdf.SetFontStyle("B")
-	pdf.SetTextColor(10, 10, 10)
-	pdf.MultiCell(0, fontSizeHeading, heading, "", "", false)
+	pdf.setTextColor(pdf.accent)
+	pdf.MultiCell(0, pdf.fontSize, title, "", "", false)
+	pdf.Ln(6)
+}
+
+// Note: Empty descriptions are skipped.
+func writeEntryDescription(pdf *pdfDoc, description string) {
+	if description == "" {
+		return
+	}
+	pdf.SetFontSize(pdf.fontSize)
+	pdf.SetFontStyle("")
+	pdf.setTextColor(pdf.text)
+	pdf.MultiCell(0, pdf.fontSize, description, "", "", false)
+	pdf.Ln(6)
+}
+
+// Writes text formatted with Markdown-lite (see package mdlite).
+func writeRichText(pdf *pdfDoc, src string) {
+	pdf.SetFontSize(pdf.fontSize)
+	pdf.setTextColor(pdf.text)
+	left, _, _, _ := pdf.GetMargins()
+	for i, block := range mdlite.Parse(src).Blocks {
+		if i > 0 {
+			pdf.Ln(6)
+		}
+		switch block.Kind {
+		case mdlite.BlockParagraph:
+			writeSpans(pdf, block.Items[0])
+			pdf.Ln(pdf.fontSize)
+		case mdlite.BlockList:
+			for j, item := range block.Items {
+				if j > 0 {
+					pdf.Ln(4)
+				}
+				pdf.SetFontStyle("")
+				pdf.CellFormat(12, pdf.fontSize, "•", "", 0, "", false, 0, "")
+				pdf.SetLeftMargin(left + 12) // Indent wrapped lines.
+				writeSpans(pdf, item)
+				pdf.SetLeftMargin(left)
+				pdf.Ln(pdf.fontSize)
+			}
+		}
+	}
+	pdf.SetFontStyle("")
+}
+
+// Note: Code is written as regular text, and so is emphasis unless a custom italic font is provided.
+func writeSpans(pdf *pdfDoc, spans []mdlite.Span) {
+	for _, span := range spans {
+		style := ""
+		if span.Bold {
+			style += "B"
+		}
+		if span.Emphasis && pdf.fonts != nil && pdf.fonts.italic {
+			style += "I"
+		}
+		if span.URL == "" {
+			pdf.SetFontStyle(style)
+			pdf.Write(pdf.fontSize, span.Text)
+			continue
+		}
+		pdf.SetFontStyle(style + "U")
+		pdf.WriteLinkString(pdf.fontSize, span.Text, span.URL)
+	}
+}
+
+func writeKV(pdf *pdfDoc, k, v string) {
+	pdf.SetFontStyle("")
+	pdf.SetFontSize(pdf.fontSize)
+	pdf.setTextColor(pdf.muted)
+	pdf.CellFormat(pdf.colLeftSize, pdf.fontSize, k, "", 0, "", false, 0, "")
+	pdf.setTextColor(pdf.text)
+	pdf.MultiCell(0, pdf.fontSize, v, "", "", false)
+	pdf.Ln(4)
+}
+
+func writeKVLink(pdf *pdfDoc, k, v, url string) {
+	pdf.SetFontStyle("")
+	pdf.SetFontSize(pdf.fontSize)
+	pdf.setTextColor(pdf.muted)
+	pdf.CellFormat(pdf.colLeftSize, pdf.fontSize, k, "", 0, "", false, 0, "")
+	pdf.setTextColor(pdf.text)
+	writeWrappedLink(pdf, v, url)
+	pdf.Ln(4)
 }
 
-func writeKV(pdf fpdf.Pdf, k, v string) {
+func writeBullet(pdf *pdfDoc, v string) {
 	pdf.SetFontStyle("")
-	pdf.SetFontSize(fontSize)
-	pdf.SetTextColor(150, 150, 150)
-	pdf.CellFormat(colLeftSize, fontSize, k, "", 0, "", false, 0, "")
-	pdf.SetTextColor(100, 100, 100)
-	pdf.MultiCell(0, fontSize, v, "", "", false)
+	pdf.SetFontSize(pdf.fontSize)
+	pdf.setTextColor(pdf.text)
+	pdf.CellFormat(12, pdf.fontSize, "•", "", 0, "", false, 0, "")
+	pdf.MultiCell(0, pdf.fontSize, v, "", "", false)
 	pdf.Ln(4)
 }
 
-func writeLink(pdf fpdf.Pdf, v Link) {
-	pdf.Ln(12)
-	pdf.SetFontSize(fontSize)
+func writeLink(pdf *pdfDoc, v Link) {
+	pdf.SetFontSize(pdf.fontSize)
 	pdf.SetFontStyle("B")
-	pdf.CellFormat(0, fontSize, v.Label, "", 1, "", false, 0, "")
+	pdf.CellFormat(0, pdf.fontSize, v.Label, "", 1, "", false, 0, "")
 	pdf.Ln(4)
-	pdf.SetFontStyle("U")
-	pdf.CellFormat(0, fontSize, v.URL, "", 2, "", false, 0, "https://"+v.URL)
+	writeWrappedLink(pdf, displayURL(v.URL), v.URL)
 }
 
-func addContactLink(pdf fpdf.Pdf, k, v, url string) {
-	pdf.Ln(8)
-	pdf.SetFontSize(fontSize)
+// Note: The label is written above the value in narrow columns.
+func addContactLink(pdf *pdfDoc, k, v, url string) {
+	pdf.SetFontSize(pdf.fontSize)
 	pdf.SetFontStyle("B")
-	pdf.CellFormat(100, fontSize, k, "", 0, "", false, 0, "")
+	if pdf.columnWidth() < 300 {
+		pdf.CellFormat(0, pdf.fontSize, k, "", 1, "", false, 0, "")
+		pdf.Ln(4)
+	} else {
+		pdf.CellFormat(100, pdf.fontSize, k, "", 0, "", false, 0, "")
+	}
+	writeWrappedLink(pdf, v, url)
+}
+
+// Writes the linked value from the current position, long values (ex: URLs) are wrapped within the column.
+// Note: Wrapped lines are aligned with the first one, the position is then moved to the next line.
+func writeWrappedLink(pdf *pdfDoc, v, url string) {
+	left, _, _, _ := pdf.GetMargins()
+	pdf.SetLeftMargin(pdf.GetX())
 	pdf.SetFontStyle("U")
-	pdf.CellFormat(0, fontSize, v, "", 2, "", false, 0, url)
+	pdf.WriteLinkString(pdf.fontSize, v, url)
+	pdf.Ln(pdf.fontSize)
+	pdf.SetLeftMargin(left)
+	pdf.SetX(left)
 }
@@ -0,0 +1,80 @@
+package nubio
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"strconv"
+	"testing"
+)
+
+func TestPDFOptions(t *testing.T) {
+	tests := []struct {
+		raw        string
+		wantErrs   int
+		wantMargin float64
+	}{
+		{`{}`, 0, 50},
+		{`{"margin": 0}`, 0, 0},
+		{`{"margin": 20, "page_size": "letter"}`, 0, 20},
+		{`{"margin": -1}`, 1, -1},
+		{`{"page_size": "Tabloid"}`, 1, 50},
+	}
+	for _, test := range tests {
+		opts := PDFOptions{}
+		err := json.Unmarshal([]byte(test.raw), &opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if errs := opts.Check(); len(errs) != test.wantErrs {
+			t.Errorf("%s: want %d error(s), got %q", test.raw, test.wantErrs, errs)
+		}
+		if margin := *opts.withDefaults().Margin; margin != test.wantMargin {
+			t.Errorf("%s: want margin %v, got %v", test.raw, test.wantMargin, margin)
+		}
+	}
+}
+
+func TestExportPDFPageSize(t *testing.T) {
+	margin := 0.0
+	conf := &ResumeConfig{Name: "Jane Doe", PDF: PDFOptions{PageSize: "letter", Margin: &margin}}
+	b := &bytes.Buffer{}
+	err := ExportPDF(b, conf, ExportOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(b.Bytes(), []byte("/MediaBox [0 0 612.00 792.00]")) {
+		t.Fatalf("want letter page size")
+	}
+}
+
+func TestExportPDFTwoColumnLongLinks(t *testing.T) {
+	margin := 50.0
+	conf := &ResumeConfig{
+		Name:         "Jane Doe",
+		Domain:       "example.com",
+		EmailAddress: "firstname.lastname@company-domain.com",
+		Links:        []Link{{Label: "LinkedIn", URL: "https://linkedin.com/in/julien-sellier-a1b2c3d4/recent-activity/all"}},
+		PDF:          PDFOptions{Theme: PDFThemeTwoColumn, Margin: &margin},
+	}
+	b := &bytes.Buffer{}
+	err := ExportPDF(b, conf, ExportOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Check that the sidebar links don't spill into the main column.
+	pageWidth := 595.28 // A4.
+	sidebarRight := margin + (pageWidth-2*margin-24)*0.35
+	rects := regexp.MustCompile(`/Subtype /Link /Rect \[([\d.]+) [\d.]+ ([\d.]+) [\d.]+\]`).FindAllSubmatch(b.Bytes(), -1)
+	if len(rects) < 3 {
+		t.Fatalf("want wrapped links (at least 3 link rectangles), got %d", len(rects))
+	}
+	for _, rect := range rects {
+		x1, _ := strconv.ParseFloat(string(rect[1]), 64)
+		x2, _ := strconv.ParseFloat(string(rect[2]), 64)
+		if x1 < sidebarRight && x2 > sidebarRight+0.01 {
+			t.Errorf("link rectangle from %v to %v exceeds the sidebar (%v)", x1, x2, sidebarRight)
+		}
+	}
+}
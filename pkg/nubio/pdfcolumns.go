@@ -0,0 +1,75 @@
+package nubio
+
+import "github.com/ejuju/nubio/pkg/mdlite"
+
+// Writes the "two-column" theme: the main column (name, description, experience, education, etc.)
+// and a sidebar on the left (avatar, contact details, links, skills and languages).
+// Note: The main column is written first, then the sidebar is written from the first page
+// (pages are only added if the sidebar is longer than the main column).
+func writeTwoColumnPDF(pdf *pdfDoc, conf *ResumeConfig) {
+	const gap = 24
+	sidebarWidth := (pdf.pageWidth - 2*pdf.margin - gap) * 0.35
+	mainLeft := pdf.margin + sidebarWidth + gap
+	sidebarRight := pdf.pageWidth - pdf.margin - sidebarWidth
+
+	// Append title (name) and short description.
+	pdf.SetLeftMargin(mainLeft)
+	pdf.AddPage()
+	pdf.SetFontSize(pdf.fontSizeTitle)
+	pdf.SetFontStyle("B")
+	pdf.setTextColor(pdf.accent)
+	pdf.MultiCell(0, pdf.fontSizeTitle, conf.Name, "", "", false)
+	pdf.Ln(pdf.fontSize)
+	pdf.SetFontSize(pdf.fontSize)
+	pdf.SetFontStyle("")
+	pdf.MultiCell(0, pdf.lineHeight, mdlite.ToPlainText(conf.Description), "", "", false)
+
+	// Append horizontal line below title.
+	pdf.Ln(pdf.fontSize)
+	pdf.setFillColor(pdf.accent)
+	pdf.Rect(mainLeft, pdf.GetY(), pdf.columnWidth(), 0.5, "F")
+
+	// Append main sections.
+	// Note: Interests and hobbies are only shown on the website.
+	sections := []pdfSection{
+		pdfWorkExperienceSection(conf),
+		pdfProjectsSection(conf),
+		pdfEducationSection(conf),
+		pdfCertificationsSection(conf),
+		pdfPublicationsSection(conf),
+		pdfTalksSection(conf),
+		pdfAwardsSection(conf),
+	}
+	sections = append(sections, pdfCustomSections(conf)...)
+	for _, section := range sections {
+		writeSection(pdf, section)
+	}
+
+	// Append sidebar, starting from the top of the first page.
+	pdf.SetMargins(pdf.margin, pdf.margin, sidebarRight)
+	pdf.SetPage(1)
+	pdf.SetY(pdf.margin)
+	pdf.SetFontSize(pdf.fontSize) // Note: The font must be set again in the page content.
+	if len(conf.Avatar) > 0 {
+		writeAvatar(pdf, conf)
+	}
+	sidebar := []pdfSection{
+		pdfContactSection(conf),
+		pdfLinksSection(conf),
+		pdfSkillsSection(conf),
+		pdfLanguagesSection(conf),
+	}
+	for _, section := range sidebar {
+		writeSection(pdf, section)
+	}
+
+	// Append vertical line between columns on each page.
+	pdf.SetLineWidth(0.5)
+	pdf.setDrawColor(pdf.muted)
+	x := pdf.margin + sidebarWidth + gap/2
+	for page := 1; page <= pdf.PageCount(); page++ {
+		pdf.SetPage(page)
+		pdf.Line(x, pdf.margin, x, pdf.pageHeight-pdf.margin)
+	}
+	pdf.SetMargins(pdf.margin, pdf.margin, pdf.margin) // Note: The footer of the last page is written on output.
+}
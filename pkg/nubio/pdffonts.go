@@ -0,0 +1,134 @@
+package nubio
+
+import (
+	"strings"
+	"sync"
+)
+
+const (
+	pdfFontFamily       = "sans-serif" // Embedded Noto Sans fonts.
+	pdfCustomFontFamily = "custom"
+)
+
+// Characters supported by the embedded Noto Sans fonts.
+var notoGlyphs = sync.OnceValue(func() *runeSet {
+	glyphs, _ := parseFontGlyphs(notoRegularTTF)
+	return glyphs
+})
+
+// Holds the custom fonts of a PDF document and the current font.
+type pdfFonts struct {
+	conf    *Fonts
+	glyphs  *runeSet // Characters supported by the custom regular font.
+	italic  bool     // Set to true if a custom italic font is provided.
+	family  string   // Current font family.
+	style   string   // Current font style (ex: "BU").
+	current string   // Current font style, as set in fpdf (italic is not available for all fonts).
+}
+
+// Registers the custom fonts (if any), they are used instead of Noto Sans when they support the written text.
+// Note: Fonts are validated on load.
+func (pdf *pdfDoc) addCustomFonts(fonts *Fonts) {
+	if fonts == nil || len(fonts.Regular) == 0 {
+		return
+	}
+	glyphs, err := parseFontGlyphs(fonts.Regular)
+	if err != nil {
+		return
+	}
+	bold := fonts.Bold
+	if len(bold) == 0 {
+		bold = fonts.Regular
+	}
+	pdf.AddUTF8FontFromBytes(pdfCustomFontFamily, "", fonts.Regular)
+	pdf.AddUTF8FontFromBytes(pdfCustomFontFamily, "B", bold)
+	if len(fonts.Italic) > 0 {
+		pdf.AddUTF8FontFromBytes(pdfCustomFontFamily, "I", fonts.Italic)
+	}
+	pdf.fonts = &pdfFonts{conf: fonts, glyphs: glyphs, italic: len(fonts.Italic) > 0}
+	pdf.setFontFamily(pdfCustomFontFamily)
+}
+
+// Returns the font family used to write the characters:
+// the custom font if it supports all of them, otherwise Noto Sans if it does.
+func (pdf *pdfDoc) fontFamilyFor(txt string) string {
+	if !pdf.fonts.glyphs.containsAll(txt) && notoGlyphs().containsAll(txt) {
+		return pdfFontFamily
+	}
+	return pdfCustomFontFamily
+}
+
+// Selects the font family for the text (only if custom fonts are provided).
+func (pdf *pdfDoc) selectFont(txt string) {
+	if pdf.fonts != nil {
+		pdf.setFontFamily(pdf.fontFamilyFor(txt))
+	}
+}
+
+func (pdf *pdfDoc) setFontFamily(family string) {
+	style := pdf.fonts.style
+	if family != pdfCustomFontFamily || strings.Contains(style, "B") {
+		style = strings.ReplaceAll(style, "I", "") // Note: Italic is only available in the custom regular weight.
+	}
+	if family == pdf.fonts.family && style == pdf.fonts.current {
+		return // Note: fpdf writes the font to the page content on each call.
+	}
+	pdf.fonts.family, pdf.fonts.current = family, style
+	pdf.Fpdf.SetFont(family, style, 0)
+}
+
+// Note: When custom fonts are provided, the font family is selected when writing text.
+func (pdf *pdfDoc) SetFontStyle(style string) {
+	if pdf.fonts == nil {
+		pdf.Fpdf.SetFontStyle(style)
+		return
+	}
+	pdf.fonts.style = style
+	pdf.setFontFamily(pdf.fonts.family)
+}
+
+func (pdf *pdfDoc) MultiCell(w, h float64, txt, border, align string, fill bool) {
+	pdf.selectFont(txt)
+	pdf.Fpdf.MultiCell(w, h, txt, border, align, fill)
+}
+
+func (pdf *pdfDoc) CellFormat(w, h float64, txt, border string, ln int, align string, fill bool, link int, linkStr string) {
+	pdf.selectFont(txt)
+	pdf.Fpdf.CellFormat(w, h, txt, border, ln, align, fill, link, linkStr)
+}
+
+func (pdf *pdfDoc) Text(x, y float64, txt string) {
+	pdf.selectFont(txt)
+	pdf.Fpdf.Text(x, y, txt)
+}
+
+func (pdf *pdfDoc) Write(h float64, txt string) {
+	pdf.writeRuns(txt, func(run string) { pdf.Fpdf.Write(h, run) })
+}
+
+func (pdf *pdfDoc) WriteLinkString(h float64, txt, url string) {
+	pdf.writeRuns(txt, func(run string) { pdf.Fpdf.WriteLinkString(h, run, url) })
+}
+
+// Writes the text in runs of characters with the same font family,
+// so only the characters missing from the custom fonts are written with Noto Sans.
+func (pdf *pdfDoc) writeRuns(txt string, write func(run string)) {
+	if pdf.fonts == nil {
+		write(txt)
+		return
+	}
+	start, family := 0, ""
+	for i, r := range txt {
+		next := pdf.fontFamilyFor(string(r))
+		if i > 0 && next != family {
+			pdf.setFontFamily(family)
+			write(txt[start:i])
+			start = i
+		}
+		family = next
+	}
+	if start < len(txt) {
+		pdf.setFontFamily(family)
+		write(txt[start:])
+	}
+}
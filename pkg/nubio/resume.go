@@ -4,8 +4,10 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"net/url"
+	"io"
 	"os"
+	"slices"
+	"strings"
 	"time"
 	"unicode/utf8"
 
@@ -14,9 +16,11 @@ import (
 
 // Holds necessary information for rendering a resume.
 type ResumeConfig struct {
+	SchemaVersion int `json:"schema_version"` // Optional: Version of the config layout (see CurrentSchemaVersion).
+
 	Slug        string `json:"slug"`        // Optional: Name as a URI-compatible slug (ex: "alex-doe").
 	Name        string `json:"name"`        // Full name (ex: "Alex Doe").
-	Description string `json:"description"` // Short description.
+	Description string `json:"description"` // Short description (supports Markdown-lite formatting).
 
 	// Public domain name (ex: "alexdoe.example")
 	// Note:
@@ -24,18 +28,47 @@ type ResumeConfig struct {
 	//	- For server: This field is overwritten by corresponding app config field.
 	Domain         string           `json:"domain"`
 	EmailAddress   string           `json:"email_address"`
+	ContactDetails ContactDetails   `json:"contact_details"` // Optional: Phone numbers, location, etc.
 	Links          []Link           `json:"links"`
 	WorkExperience []WorkExperience `json:"work_experience"`
+	Projects       []Project        `json:"projects"`
 	Skills         []Skill          `json:"skills"`
 	Languages      []Language       `json:"languages"`
 	Education      []Education      `json:"education"`
+	Certifications []Certification  `json:"certifications"`
+	Publications   []Publication    `json:"publications"`
+	Talks          []Talk           `json:"talks"`
+	Awards         []Award          `json:"awards"`
 	Interests      []string         `json:"interests"`
 	Hobbies        []string         `json:"hobbies"`
+	CustomSections []CustomSection  `json:"custom_sections"` // Optional: Sections rendered after built-in ones.
+
+	// Optional: Protects the email address from scrapers on the HTML page
+	// (one of: "entities", "js" or "redirect").
+	// When set, the email address is also omitted from the public JSON and vCard exports.
+	EmailObfuscation string `json:"email_obfuscation"`
+
+	// Optional: Maximum number of highlights rendered per entry (0 means no limit).
+	// Useful for shorter variants of the same resume.
+	MaxHighlights int `json:"max_highlights"`
+
+	// Set to true to omit certifications that are expired at export time.
+	HideExpiredCertifications bool `json:"hide_expired_certifications"`
+
+	PDF   PDFOptions `json:"pdf"`   // Optional: Page size, margins, typography and colors of the PDF export.
+	Fonts Fonts      `json:"fonts"` // Optional: Custom fonts for the PDF and HTML exports.
 
 	CustomCSSPath string `json:"custom_css_path"` // Path to custom CSS stylesheet. Not exported.
 	CustomCSS     string `json:"custom_css"`      // Literal value or populated by the corresponding file's content on load.
 	InlineCSS     bool   `json:"inline_css"`      // Set to true to include CSS directly in HTML.
 
+	// Path to profile photo (JPEG or PNG). Not exported.
+	// Note: The avatar is served by the server on "/avatar.jpg" or "/avatar.png".
+	AvatarPath    string `json:"avatar_path"`
+	AvatarMaxSize int    `json:"avatar_max_size"` // Optional: Downscale and re-encode avatar on load (max width and height in pixels).
+	Avatar        []byte `json:"-"`               // Populated by the avatar file's content on load.
+	AvatarFormat  string `json:"-"`               // Populated on load ("jpeg" or "png").
+
 	// Public PGP key URL (without leading "https://").
 	// This field is overwritten on startup if a PGP key is provided in the app config.
 	PGPKeyURL  string `json:"pgp_key_url"`
@@ -44,11 +77,21 @@ type ResumeConfig struct {
 }
 
 // Read and decode resume config file.
+// Note: The config is read from stdin if the path is "-".
 func LoadResumeConfig(path string) (conf *ResumeConfig, err error) {
-	b, err := os.ReadFile(path)
+	b, err := readFileOrStdin(path)
 	if err != nil {
 		return nil, fmt.Errorf("read config file: %w", err)
 	}
+	return loadResumeConfig(b, path)
+}
+
+// Decodes and loads the resume config file content (the path is only used in error messages).
+func loadResumeConfig(b []byte, path string) (conf *ResumeConfig, err error) {
+	err = checkSchema(b, path)
+	if err != nil {
+		return nil, err
+	}
 	conf = &ResumeConfig{}
 	err = json.Unmarshal(b, conf)
 	if err != nil {
@@ -69,6 +112,16 @@ func LoadResumeConfig(path string) (conf *ResumeConfig, err error) {
 		}
 	}
 
+	normalizeResumeURLs(conf)
+
+	// Load avatar if provided.
+	if conf.AvatarPath != "" {
+		conf.Avatar, conf.AvatarFormat, err = loadAvatar(conf.AvatarPath, conf.AvatarMaxSize)
+		if err != nil {
+			return nil, fmt.Errorf("load avatar: %w", err)
+		}
+	}
+
 	// Load PGP key if provided.
 	if conf.PGPKeyPath != "" {
 		b, err = os.ReadFile(conf.PGPKeyPath)
@@ -79,6 +132,12 @@ func LoadResumeConfig(path string) (conf *ResumeConfig, err error) {
 		conf.PGPKeyURL = conf.Domain + PathPGPKey
 	}
 
+	// Load custom fonts if provided.
+	err = conf.Fonts.load()
+	if err != nil {
+		return nil, fmt.Errorf("load fonts: %w", err)
+	}
+
 	// Load custom CSS if provided.
 	if conf.CustomCSSPath != "" {
 		b, err = os.ReadFile(conf.CustomCSSPath)
@@ -91,6 +150,13 @@ func LoadResumeConfig(path string) (conf *ResumeConfig, err error) {
 	return conf, nil
 }
 
+func readFileOrStdin(path string) ([]byte, error) {
+	if path == "-" {
+		return io.ReadAll(os.Stdin)
+	}
+	return os.ReadFile(path)
+}
+
 func (conf *ServerConfig) Check() (errs []error) {
 	if conf.ResumePath == "" {
 		errs = append(errs, errors.New("missing resume path"))
@@ -115,11 +181,26 @@ func (p *ResumeConfig) Check() (errs []error) {
 	if p.Domain == "" {
 		errs = append(errs, errors.New("missing domain"))
 	}
+	if p.SchemaVersion < 0 || p.SchemaVersion > CurrentSchemaVersion {
+		errs = append(errs, fmt.Errorf("unsupported schema version: %d", p.SchemaVersion))
+	}
+	if p.AvatarMaxSize < 0 || (p.AvatarMaxSize > 0 && p.AvatarMaxSize < minAvatarDimension) {
+		errs = append(errs, fmt.Errorf("invalid avatar max size: %d", p.AvatarMaxSize))
+	}
+	if p.MaxHighlights < 0 {
+		errs = append(errs, fmt.Errorf("invalid max highlights: %d", p.MaxHighlights))
+	}
 
 	// Check contact info.
 	if p.EmailAddress == "" {
 		errs = append(errs, errors.New("missing email address"))
 	}
+	if err := checkEmailObfuscation(p.EmailObfuscation); err != nil {
+		errs = append(errs, err)
+	}
+	for _, err := range p.ContactDetails.Check() {
+		errs = append(errs, fmt.Errorf("contact details: %w", err))
+	}
 
 	// Check links.
 	if len(p.Links) == 0 {
@@ -141,6 +222,13 @@ func (p *ResumeConfig) Check() (errs []error) {
 		}
 	}
 
+	// Check projects.
+	for i, v := range p.Projects {
+		for _, err := range v.Check() {
+			errs = append(errs, fmt.Errorf("project %d: %w", i, err))
+		}
+	}
+
 	// Check skills.
 	if len(p.Skills) == 0 {
 		errs = append(errs, errors.New("missing skills"))
@@ -171,31 +259,90 @@ func (p *ResumeConfig) Check() (errs []error) {
 		}
 	}
 
+	// Check certifications.
+	for i, v := range p.Certifications {
+		for _, err := range v.Check() {
+			errs = append(errs, fmt.Errorf("certification %d: %w", i, err))
+		}
+	}
+
+	// Check publications.
+	for i, v := range p.Publications {
+		for _, err := range v.Check() {
+			errs = append(errs, fmt.Errorf("publication %d: %w", i, err))
+		}
+	}
+
+	// Check talks.
+	for i, v := range p.Talks {
+		for _, err := range v.Check() {
+			errs = append(errs, fmt.Errorf("talk %d: %w", i, err))
+		}
+	}
+
+	// Check awards.
+	for i, v := range p.Awards {
+		for _, err := range v.Check() {
+			errs = append(errs, fmt.Errorf("award %d: %w", i, err))
+		}
+	}
+
+	// Check custom sections.
+	for i, v := range p.CustomSections {
+		for _, err := range v.Check() {
+			errs = append(errs, fmt.Errorf("custom section %d: %w", i, err))
+		}
+	}
+
 	// Check interests.
 	for i, v := range p.Interests {
-		for v == "" {
+		if v == "" {
 			errs = append(errs, fmt.Errorf("interest %d: empty text", i))
 		}
 	}
 
 	// Check hobbies.
 	for i, v := range p.Hobbies {
-		for v == "" {
+		if v == "" {
 			errs = append(errs, fmt.Errorf("hobby %d: empty text", i))
 		}
 	}
 
+	// Check PDF options.
+	for _, err := range p.PDF.Check() {
+		errs = append(errs, fmt.Errorf("pdf: %w", err))
+	}
+
+	// Check fonts.
+	for _, err := range p.Fonts.Check() {
+		errs = append(errs, fmt.Errorf("fonts: %w", err))
+	}
+
 	return errs
 }
 
+// Reports issues that don't prevent rendering the resume,
+// but that should probably be fixed (ex: expired certifications).
+func (p *ResumeConfig) Warnings() (warns []error) {
+	now := time.Now()
+	for i, v := range p.Certifications {
+		if v.IsExpired(now) && !p.HideExpiredCertifications {
+			warns = append(warns, fmt.Errorf("certification %d: expired since %s", i, v.Expiry))
+		}
+	}
+	return warns
+}
+
 type WorkExperience struct {
-	From         string   `json:"from"`
-	To           string   `json:"to"`
-	Title        string   `json:"title"`
-	Organization string   `json:"organization"`
-	Location     string   `json:"location"`
-	Description  string   `json:"description"`
-	Skills       []string `json:"skills"`
+	From         string     `json:"from"`
+	To           string     `json:"to"`
+	Title        string     `json:"title"`
+	Organization string     `json:"organization"`
+	Location     string     `json:"location"`
+	Description  string     `json:"description"` // Supports Markdown-lite formatting.
+	Highlights   []string   `json:"highlights"`  // Optional: Achievements (ex: "Reduced latency by 30%").
+	Skills       []string   `json:"skills"`
+	Visibility   Visibility `json:"visibility,omitempty"`
 }
 
 const DateLayout = "January 2006"
@@ -231,16 +378,105 @@ func (v *WorkExperience) Check() (errs []error) {
 	if v.Description == "" {
 		errs = append(errs, errors.New("missing description"))
 	}
+	for i, v := range v.Highlights {
+		if v == "" {
+			errs = append(errs, fmt.Errorf("highlight %d: empty text", i))
+		}
+	}
 	if len(v.Skills) == 0 {
 		errs = append(errs, errors.New("missing skills"))
 	}
 
+	if err := v.Visibility.Check(); err != nil {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+type Project struct {
+	Name         string     `json:"name"`
+	URL          string     `json:"url"`         // Optional: Website URL.
+	Repository   string     `json:"repository"`  // Optional: Source code repository URL.
+	Description  string     `json:"description"` // Supports Markdown-lite formatting.
+	Role         string     `json:"role"`        // Optional (ex: "Maintainer").
+	From         string     `json:"from"`        // Optional.
+	To           string     `json:"to"`          // Optional.
+	Technologies []string   `json:"technologies"`
+	Highlights   []string   `json:"highlights"`
+	Visibility   Visibility `json:"visibility,omitempty"`
+}
+
+// Note: Dates are optional, but an end date requires a start date.
+func (v *Project) Check() (errs []error) {
+	if v.Name == "" {
+		errs = append(errs, errors.New("missing name"))
+	}
+	if v.Description == "" {
+		errs = append(errs, errors.New("missing description"))
+	}
+	if v.URL != "" {
+		if err := checkURL(v.URL); err != nil {
+			errs = append(errs, fmt.Errorf("invalid URL: %w", err))
+		}
+	}
+	if v.Repository != "" {
+		if err := checkURL(v.Repository); err != nil {
+			errs = append(errs, fmt.Errorf("invalid repository URL: %w", err))
+		}
+	}
+
+	errs = append(errs, checkOptionalDateRange(v.From, v.To)...)
+	for i, v := range v.Technologies {
+		if v == "" {
+			errs = append(errs, fmt.Errorf("technology %d: empty text", i))
+		}
+	}
+	for i, v := range v.Highlights {
+		if v == "" {
+			errs = append(errs, fmt.Errorf("highlight %d: empty text", i))
+		}
+	}
+	if err := v.Visibility.Check(); err != nil {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+// Returns at most max highlights (or all of them if max is zero or less).
+func limitHighlights(highlights []string, max int) []string {
+	if max <= 0 || len(highlights) <= max {
+		return highlights
+	}
+	return highlights[:max]
+}
+
+// Checks a date range where both dates are optional,
+// but an end date requires a start date.
+func checkOptionalDateRange(rawFrom, rawTo string) (errs []error) {
+	from := minExpDate
+	if rawFrom != "" {
+		var err error
+		from, err = parseDateMinMax(DateLayout, rawFrom, minExpDate, maxExpDate)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid start date: %w", err))
+		}
+	}
+	if rawTo != "" {
+		if rawFrom == "" {
+			errs = append(errs, errors.New("missing start date"))
+		}
+		_, err := parseDateMinMax(DateLayout, rawTo, from, maxExpDate)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid end date: %w", err))
+		}
+	}
 	return errs
 }
 
 type Skill struct {
-	Title string   `json:"title"`
-	Tools []string `json:"tools"`
+	Title      string     `json:"title"`
+	Tools      []Tool     `json:"tools"` // Either names (ex: "Go") or objects (ex: {"name": "Go", "level": 4}).
+	Visibility Visibility `json:"visibility,omitempty"`
 }
 
 func (v *Skill) Check() (errs []error) {
@@ -251,18 +487,50 @@ func (v *Skill) Check() (errs []error) {
 		errs = append(errs, errors.New("missing tools"))
 	}
 	for i, v := range v.Tools {
-		if v == "" {
-			errs = append(errs, fmt.Errorf("empty text at index %d", i))
+		for _, err := range v.Check() {
+			errs = append(errs, fmt.Errorf("tool %d: %w", i, err))
 		}
 	}
+	if err := v.Visibility.Check(); err != nil {
+		errs = append(errs, err)
+	}
 	return errs
 }
 
+// Counts, for each tool listed in the skills section,
+// the number of work experiences and projects in which it was used.
+// Tools are matched case-insensitively.
+func (p *ResumeConfig) CountToolUsage() map[string]int {
+	counts := map[string]int{}
+	for _, skill := range p.Skills {
+		for _, tool := range toolNames(skill.Tools) {
+			if _, ok := counts[tool]; ok {
+				continue // Tool is listed in multiple skills.
+			}
+			isTool := func(s string) bool { return strings.EqualFold(s, tool) }
+			counts[tool] = 0
+			for _, v := range p.WorkExperience {
+				if slices.ContainsFunc(v.Skills, isTool) {
+					counts[tool]++
+				}
+			}
+			for _, v := range p.Projects {
+				if slices.ContainsFunc(v.Technologies, isTool) {
+					counts[tool]++
+				}
+			}
+		}
+	}
+	return counts
+}
+
 type Education struct {
-	From         string `json:"from"`
-	To           string `json:"to"`
-	Title        string `json:"title"`
-	Organization string `json:"organization"`
+	From         string     `json:"from"`
+	To           string     `json:"to"`
+	Title        string     `json:"title"`
+	Organization string     `json:"organization"`
+	Highlights   []string   `json:"highlights"` // Optional (ex: "Graduated with honors").
+	Visibility   Visibility `json:"visibility,omitempty"`
 }
 
 func (v *Education) Check() (errs []error) {
@@ -287,12 +555,227 @@ func (v *Education) Check() (errs []error) {
 	if v.Organization == "" {
 		errs = append(errs, errors.New("missing organization"))
 	}
+	for i, v := range v.Highlights {
+		if v == "" {
+			errs = append(errs, fmt.Errorf("highlight %d: empty text", i))
+		}
+	}
+	if err := v.Visibility.Check(); err != nil {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+type Certification struct {
+	Title         string     `json:"title"`
+	Issuer        string     `json:"issuer"`
+	Date          string     `json:"date"`                 // Date of issuance.
+	Expiry        string     `json:"expiry"`               // Optional: Expiry date (last valid month).
+	CredentialURL string     `json:"credential_url"`       // Optional: Credential URL.
+	Visibility    Visibility `json:"visibility,omitempty"` // Optional: "public" (default), "private" or "pdf-only".
+}
+
+func (v *Certification) Check() (errs []error) {
+	if v.Title == "" {
+		errs = append(errs, errors.New("missing title"))
+	}
+	if v.Issuer == "" {
+		errs = append(errs, errors.New("missing issuer"))
+	}
+	date, err := parseDateMinMax(DateLayout, v.Date, minExpDate, maxExpDate)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("invalid date: %w", err))
+	}
+	if v.Expiry != "" {
+		_, err = parseDateMinMax(DateLayout, v.Expiry, date, maxExpDate)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid expiry date: %w", err))
+		}
+	}
+	if v.CredentialURL != "" {
+		if err := checkURL(v.CredentialURL); err != nil {
+			errs = append(errs, fmt.Errorf("invalid credential URL: %w", err))
+		}
+	}
+	if err := v.Visibility.Check(); err != nil {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+// Reports whether the certification has expired at the given time.
+// Note: Certifications without (valid) expiry date never expire.
+func (v *Certification) IsExpired(now time.Time) bool {
+	if v.Expiry == "" || v.Expiry == "now" {
+		return false
+	}
+	expiry, err := time.Parse(DateLayout, v.Expiry)
+	if err != nil {
+		return false
+	}
+	return !now.Before(expiry.AddDate(0, 1, 0))
+}
+
+type Publication struct {
+	Title       string     `json:"title"`
+	Publisher   string     `json:"publisher"` // Journal, conference proceedings, blog, etc.
+	Date        string     `json:"date"`
+	URL         string     `json:"url"`                  // Optional.
+	Authors     []string   `json:"authors"`              // Optional: Co-authors.
+	Description string     `json:"description"`          // Optional.
+	Visibility  Visibility `json:"visibility,omitempty"` // Optional: "public" (default), "private" or "pdf-only".
+}
+
+func (v *Publication) Check() (errs []error) {
+	if v.Title == "" {
+		errs = append(errs, errors.New("missing title"))
+	}
+	if v.Publisher == "" {
+		errs = append(errs, errors.New("missing publisher"))
+	}
+	_, err := parseDateMinMax(DateLayout, v.Date, minExpDate, maxExpDate)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("invalid date: %w", err))
+	}
+	if v.URL != "" {
+		if err := checkURL(v.URL); err != nil {
+			errs = append(errs, fmt.Errorf("invalid URL: %w", err))
+		}
+	}
+	for i, v := range v.Authors {
+		if v == "" {
+			errs = append(errs, fmt.Errorf("author %d: empty text", i))
+		}
+	}
+	if err := v.Visibility.Check(); err != nil {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+type Talk struct {
+	Title       string     `json:"title"`
+	Event       string     `json:"event"`
+	Location    string     `json:"location"` // Optional (ex: "Paris, France" or "Online").
+	Date        string     `json:"date"`
+	URL         string     `json:"url"`                  // Optional: Slides or recording.
+	Description string     `json:"description"`          // Optional.
+	Visibility  Visibility `json:"visibility,omitempty"` // Optional: "public" (default), "private" or "pdf-only".
+}
+
+func (v *Talk) Check() (errs []error) {
+	if v.Title == "" {
+		errs = append(errs, errors.New("missing title"))
+	}
+	if v.Event == "" {
+		errs = append(errs, errors.New("missing event"))
+	}
+	_, err := parseDateMinMax(DateLayout, v.Date, minExpDate, maxExpDate)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("invalid date: %w", err))
+	}
+	if v.URL != "" {
+		if err := checkURL(v.URL); err != nil {
+			errs = append(errs, fmt.Errorf("invalid URL: %w", err))
+		}
+	}
+	if err := v.Visibility.Check(); err != nil {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+type Award struct {
+	Title       string     `json:"title"`
+	Issuer      string     `json:"issuer"`
+	Date        string     `json:"date"`
+	Description string     `json:"description"`          // Optional.
+	Visibility  Visibility `json:"visibility,omitempty"` // Optional: "public" (default), "private" or "pdf-only".
+}
+
+func (v *Award) Check() (errs []error) {
+	if v.Title == "" {
+		errs = append(errs, errors.New("missing title"))
+	}
+	if v.Issuer == "" {
+		errs = append(errs, errors.New("missing issuer"))
+	}
+	_, err := parseDateMinMax(DateLayout, v.Date, minExpDate, maxExpDate)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("invalid date: %w", err))
+	}
+	if err := v.Visibility.Check(); err != nil {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+// Holds a section that has no dedicated type (ex: "Volunteering").
+type CustomSection struct {
+	Heading    string               `json:"heading"`
+	Icon       string               `json:"icon"` // Optional: Short text or emoji shown before the heading (HTML only).
+	Entries    []CustomSectionEntry `json:"entries"`
+	Visibility Visibility           `json:"visibility,omitempty"`
+}
+
+func (v *CustomSection) Check() (errs []error) {
+	if v.Heading == "" {
+		errs = append(errs, errors.New("missing heading"))
+	}
+	if iconSize := utf8.RuneCountInString(v.Icon); iconSize > 8 {
+		errs = append(errs, fmt.Errorf("icon is too big: %d characters", iconSize))
+	}
+	if len(v.Entries) == 0 {
+		errs = append(errs, errors.New("missing entries"))
+	}
+	for i, v := range v.Entries {
+		for _, err := range v.Check() {
+			errs = append(errs, fmt.Errorf("entry %d: %w", i, err))
+		}
+	}
+	if err := v.Visibility.Check(); err != nil {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+// Note: Only the title is required.
+type CustomSectionEntry struct {
+	Title      string     `json:"title"`
+	Subtitle   string     `json:"subtitle"`
+	From       string     `json:"from"`
+	To         string     `json:"to"`
+	Body       string     `json:"body"` // Supports Markdown-lite formatting.
+	Tags       []string   `json:"tags"`
+	Links      []Link     `json:"links"`
+	Visibility Visibility `json:"visibility,omitempty"`
+}
+
+func (v *CustomSectionEntry) Check() (errs []error) {
+	if v.Title == "" {
+		errs = append(errs, errors.New("missing title"))
+	}
+	errs = append(errs, checkOptionalDateRange(v.From, v.To)...)
+	for i, v := range v.Tags {
+		if v == "" {
+			errs = append(errs, fmt.Errorf("tag %d: empty text", i))
+		}
+	}
+	for i, v := range v.Links {
+		for _, err := range v.Check() {
+			errs = append(errs, fmt.Errorf("link %d: %w", i, err))
+		}
+	}
+	if err := v.Visibility.Check(); err != nil {
+		errs = append(errs, err)
+	}
 	return errs
 }
 
 type Language struct {
-	Label       string `json:"label"`
-	Proficiency string `json:"proficiency"`
+	Label       string     `json:"label"`
+	Proficiency string     `json:"proficiency"`
+	Visibility  Visibility `json:"visibility,omitempty"`
 }
 
 func (v *Language) Check() (errs []error) {
@@ -302,12 +785,21 @@ func (v *Language) Check() (errs []error) {
 	if v.Proficiency == "" {
 		errs = append(errs, errors.New("missing proficiency"))
 	}
+	if err := v.Visibility.Check(); err != nil {
+		errs = append(errs, err)
+	}
 	return errs
 }
 
 type Link struct {
 	Label string `json:"label"`
-	URL   string `json:"url"`
+
+	// Absolute URL (ex: "https://github.com/alexdoe", "mailto:alex@example.com" or "tel:+33612345678").
+	// Note: Legacy URLs without scheme (ex: "github.com/alexdoe") are prefixed with "https://" on load.
+	URL string `json:"url"`
+
+	Kind       string     `json:"kind"` // Optional: Used to pick an icon (ex: "github"), detected from the URL on load if empty.
+	Visibility Visibility `json:"visibility,omitempty"`
 }
 
 func (v *Link) Check() (errs []error) {
@@ -316,9 +808,15 @@ func (v *Link) Check() (errs []error) {
 	}
 	if v.URL == "" {
 		errs = append(errs, errors.New("missing URL"))
-	} else if _, err := url.Parse(v.URL); err != nil {
+	} else if err := checkURL(v.URL); err != nil {
 		errs = append(errs, fmt.Errorf("invalid URL: %w", err))
 	}
+	if v.Kind != "" && !slices.Contains(linkKinds, v.Kind) {
+		errs = append(errs, fmt.Errorf("unknown kind: %q", v.Kind))
+	}
+	if err := v.Visibility.Check(); err != nil {
+		errs = append(errs, err)
+	}
 	return errs
 }
 
@@ -0,0 +1,223 @@
+package nubio
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"maps"
+	"os"
+	"strings"
+)
+
+// A value asked by the "init" command (either provided as a flag or interactively).
+type initField struct {
+	Flag     string // Flag name (ex: "name" for "--name=Alex Doe").
+	Prompt   string // Question asked interactively.
+	Fallback string // Optional: Default value.
+}
+
+// Values asked by the "init" command (in order).
+var initFields = []initField{
+	{Flag: "name", Prompt: "Full name"},
+	{Flag: "domain", Prompt: "Domain name (ex: alexdoe.example)"},
+	{Flag: "email", Prompt: "Email address"},
+	{Flag: "link-label", Prompt: "Link label", Fallback: "GitHub"},
+	{Flag: "link-url", Prompt: "Link URL (ex: https://github.com/alexdoe)"},
+	{Flag: "job-title", Prompt: "Current (or last) job title"},
+	{Flag: "job-organization", Prompt: "Organization"},
+	{Flag: "job-location", Prompt: "Location (ex: Paris, France)"},
+	{Flag: "job-from", Prompt: "Start date (ex: May 2021)"},
+	{Flag: "job-to", Prompt: "End date", Fallback: "now"},
+	{Flag: "job-description", Prompt: "Short description of your job"},
+	{Flag: "job-skills", Prompt: "Skills used (comma-separated)"},
+	{Flag: "skill-title", Prompt: "Skill category", Fallback: "Software development"},
+	{Flag: "skill-tools", Prompt: "Tools (comma-separated)"},
+	{Flag: "language", Prompt: "Language", Fallback: "English"},
+	{Flag: "language-proficiency", Prompt: "Proficiency", Fallback: "Native"},
+	{Flag: "education-title", Prompt: "Degree (or training) title"},
+	{Flag: "education-organization", Prompt: "School (or organization)"},
+	{Flag: "education-from", Prompt: "Start date"},
+	{Flag: "education-to", Prompt: "End date"},
+	{Flag: "server-address", Prompt: "Server address", Fallback: ":8080"},
+}
+
+type InitOptions struct {
+	ResumePath string            // Defaults to "resume.json".
+	ServerPath string            // Optional: Also create a server config file.
+	Force      bool              // Overwrite existing files.
+	NoInput    bool              // Use default values instead of asking missing values.
+	Values     map[string]string // Values of init fields, by flag name (ex: "name": "Alex Doe").
+}
+
+// Creates a new resume config file (and optionally a server config file).
+// Missing values are asked interactively (unless NoInput is set).
+func RunInit(opts InitOptions) (exitcode int) {
+	values := maps.Clone(opts.Values)
+	if values == nil {
+		values = map[string]string{}
+	}
+	resumePath, serverPath := opts.ResumePath, opts.ServerPath
+	if resumePath == "" {
+		resumePath = "resume.json"
+	}
+	if values["server-address"] != "" && serverPath == "" {
+		serverPath = "server.json"
+	}
+
+	// Refuse to overwrite existing files.
+	for _, path := range []string{resumePath, serverPath} {
+		if path == "" || opts.Force {
+			continue
+		}
+		if _, err := os.Stat(path); err == nil {
+			log.Printf("%s already exists (use --force to overwrite it)", path)
+			return 1
+		} else if !errors.Is(err, os.ErrNotExist) {
+			log.Printf("check %s: %s", path, err)
+			return 1
+		}
+	}
+
+	// Ask missing values.
+	in := bufio.NewReader(os.Stdin)
+	for _, field := range initFields {
+		if field.Flag == "server-address" && serverPath == "" {
+			continue
+		}
+		if values[field.Flag] != "" {
+			continue
+		}
+		if opts.NoInput {
+			values[field.Flag] = field.Fallback
+			continue
+		}
+		answer, err := ask(os.Stdout, in, field.Prompt, field.Fallback)
+		if err != nil {
+			log.Printf("read answer: %s", err)
+			return 1
+		}
+		values[field.Flag] = answer
+	}
+
+	// Create and check resume config.
+	jobFrom, jobTo := values["job-from"], values["job-to"]
+	educationFrom, educationTo := values["education-from"], values["education-to"]
+	normalizeDates(&jobFrom, &jobTo, &educationFrom, &educationTo)
+	resumeConf := &ResumeConfig{
+		SchemaVersion: CurrentSchemaVersion,
+		Name:          values["name"],
+		Domain:        values["domain"],
+		EmailAddress:  values["email"],
+		Links:         []Link{{Label: values["link-label"], URL: normalizeURL(values["link-url"])}},
+		WorkExperience: []WorkExperience{{
+			From:         jobFrom,
+			To:           jobTo,
+			Title:        values["job-title"],
+			Organization: values["job-organization"],
+			Location:     values["job-location"],
+			Description:  values["job-description"],
+			Skills:       splitList(values["job-skills"]),
+		}},
+		Skills: []Skill{{
+			Title: values["skill-title"],
+			Tools: toolsFromNames(splitList(values["skill-tools"])),
+		}},
+		Languages: []Language{{Label: values["language"], Proficiency: values["language-proficiency"]}},
+		Education: []Education{{
+			From:         educationFrom,
+			To:           educationTo,
+			Title:        values["education-title"],
+			Organization: values["education-organization"],
+		}},
+	}
+	errs := resumeConf.Check()
+	if len(errs) > 0 {
+		for _, err := range errs {
+			log.Printf("- %s", err)
+		}
+		log.Printf("resume config is invalid, nothing was written")
+		return 1
+	}
+
+	// Create and check server config if needed.
+	var serverConf *ServerConfig
+	if serverPath != "" {
+		serverConf = &ServerConfig{Address: values["server-address"], ResumePath: resumePath}
+		errs := serverConf.Check()
+		if len(errs) > 0 {
+			for _, err := range errs {
+				log.Printf("- %s", err)
+			}
+			log.Printf("server config is invalid, nothing was written")
+			return 1
+		}
+	}
+
+	// Write files.
+	b, err := json.Marshal(resumeConf)
+	if err == nil {
+		b, err = FormatResumeConfig(b, FormatOptions{})
+	}
+	if err != nil {
+		log.Printf("encode resume config: %s", err)
+		return 1
+	}
+	err = os.WriteFile(resumePath, b, 0666)
+	if err != nil {
+		log.Printf("write resume config: %s", err)
+		return 1
+	}
+	log.Printf("wrote %s", resumePath)
+	if serverConf != nil {
+		b, err := json.MarshalIndent(serverConf, "", "    ")
+		if err != nil {
+			log.Printf("encode server config: %s", err)
+			return 1
+		}
+		err = os.WriteFile(serverPath, append(b, '\n'), 0666)
+		if err != nil {
+			log.Printf("write server config: %s", err)
+			return 1
+		}
+		log.Printf("wrote %s", serverPath)
+	}
+	return 0
+}
+
+// Prints the prompt and returns the answer (or the fallback if the answer is empty).
+func ask(w io.Writer, r *bufio.Reader, prompt, fallback string) (string, error) {
+	if fallback != "" {
+		prompt += " [" + fallback + "]"
+	}
+	fmt.Fprintf(w, "%s: ", prompt)
+	answer, err := r.ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", err
+	}
+	answer = strings.TrimSpace(answer)
+	if answer == "" {
+		return fallback, nil
+	}
+	return answer, nil
+}
+
+// Splits a comma-separated list (empty items are omitted).
+func splitList(v string) (items []string) {
+	for _, item := range strings.Split(v, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func toolsFromNames(names []string) []Tool {
+	tools := make([]Tool, len(names))
+	for i, name := range names {
+		tools[i] = Tool{Name: name}
+	}
+	return tools
+}
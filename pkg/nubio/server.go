@@ -43,6 +43,10 @@ func LoadServerConfig(path string) (conf *ServerConfig, err error) {
 	if err != nil {
 		return nil, fmt.Errorf("read: %w", err)
 	}
+	doc := map[string]json.RawMessage{}
+	if json.Unmarshal(b, &doc) == nil && doc["resume"] != nil {
+		return nil, fmt.Errorf(`outdated config layout ("resume" field), run "nubio migrate %s"`, path)
+	}
 	conf = &ServerConfig{}
 	err = json.Unmarshal(b, conf)
 	if err != nil {
@@ -51,18 +55,14 @@ func LoadServerConfig(path string) (conf *ServerConfig, err error) {
 	return conf, nil
 }
 
-func RunServer(args ...string) (exitcode int) {
+func RunServer(path string) (exitcode int) {
 	// Init logger.
 	slogh := slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug})
 	logger := slog.New(slogh)
 	logger.Debug("logger ready")
 
 	// Load config.
-	defaultServerConfigPath := "server.json"
-	if len(args) > 0 {
-		defaultServerConfigPath = args[0]
-	}
-	serverConf, resumeConf, err := LoadServerAndResumeConfig(defaultServerConfigPath)
+	serverConf, resumeConf, err := LoadServerAndResumeConfig(path)
 	if err != nil {
 		logger.Error("load config", "error", err)
 		return 1
@@ -81,6 +81,9 @@ func RunServer(args ...string) (exitcode int) {
 		}
 		return 1
 	}
+	for _, warn := range resumeConf.Warnings() {
+		logger.Warn("resume config", "warning", warn)
+	}
 
 	// Init and register HTTP endpoints, wrap global middleware.
 	//
@@ -0,0 +1,119 @@
+package nubio
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// A tool (or technology) listed in a skill.
+// In the resume config, a tool is either a string (its name) or an object.
+type Tool struct {
+	Name     string     `json:"name"`
+	Level    SkillLevel `json:"level,omitempty"`    // Optional: From 1 (beginner) to 5 (expert).
+	Years    int        `json:"years,omitempty"`    // Optional: Years of experience.
+	Featured bool       `json:"featured,omitempty"` // Set to true to highlight the tool.
+}
+
+// Accepts a string (legacy format, ex: "Go") or an object (ex: {"name": "Go", "level": 4}).
+func (v *Tool) UnmarshalJSON(b []byte) error {
+	if bytes.HasPrefix(bytes.TrimSpace(b), []byte(`"`)) {
+		*v = Tool{}
+		return json.Unmarshal(b, &v.Name)
+	}
+	type tool Tool // Note: Avoids infinite recursion.
+	return json.Unmarshal(b, (*tool)(v))
+}
+
+// Writes a string if only the name is set (ex: "Go"), and an object otherwise.
+func (v Tool) MarshalJSON() ([]byte, error) {
+	if v == (Tool{Name: v.Name}) {
+		return json.Marshal(v.Name)
+	}
+	type tool Tool // Note: Avoids infinite recursion.
+	return json.Marshal(tool(v))
+}
+
+func (v *Tool) Check() (errs []error) {
+	if v.Name == "" {
+		errs = append(errs, errors.New("missing name"))
+	}
+	if err := v.Level.Check(); err != nil {
+		errs = append(errs, err)
+	}
+	if v.Years < 0 {
+		errs = append(errs, fmt.Errorf("negative years of experience: %d", v.Years))
+	}
+	return errs
+}
+
+// Proficiency level, from 1 to 5 (0 means unspecified).
+// In the resume config, a level is either a number or a name (ex: "advanced").
+type SkillLevel int
+
+const MaxSkillLevel SkillLevel = 5
+
+var skillLevelNames = []string{"", "beginner", "elementary", "intermediate", "advanced", "expert"}
+
+// Accepts a number (ex: 4) or a level name (ex: "advanced").
+func (v *SkillLevel) UnmarshalJSON(b []byte) error {
+	if !bytes.HasPrefix(bytes.TrimSpace(b), []byte(`"`)) {
+		return json.Unmarshal(b, (*int)(v))
+	}
+	var name string
+	if err := json.Unmarshal(b, &name); err != nil {
+		return err
+	}
+	for i, v2 := range skillLevelNames {
+		if v2 != "" && strings.EqualFold(name, v2) {
+			*v = SkillLevel(i)
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown skill level: %q (expected one of: %s)", name, strings.Join(skillLevelNames[1:], ", "))
+}
+
+func (v SkillLevel) Check() error {
+	if v < 0 || v > MaxSkillLevel {
+		return fmt.Errorf("skill level must be between 1 and %d: %d", MaxSkillLevel, v)
+	}
+	return nil
+}
+
+// Returns the level name (ex: "advanced"), or an empty string if unspecified or invalid.
+func (v SkillLevel) String() string {
+	if v.Check() != nil {
+		return ""
+	}
+	return skillLevelNames[v]
+}
+
+// Returns one boolean per level step, true if the step is reached (used to render level indicators).
+func (v SkillLevel) Steps() []bool {
+	steps := make([]bool, MaxSkillLevel)
+	for i := range steps {
+		steps[i] = SkillLevel(i) < v
+	}
+	return steps
+}
+
+// Returns the names of the given tools.
+func toolNames(tools []Tool) []string {
+	names := make([]string, len(tools))
+	for i, v := range tools {
+		names[i] = v.Name
+	}
+	return names
+}
+
+// Reports whether a level, years of experience or featured flag is set on any of the tools.
+func hasToolDetails(tools []Tool) bool {
+	for _, v := range tools {
+		if v.Level != 0 || v.Years != 0 || v.Featured {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,33 @@
+package nubio
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestToolJSON(t *testing.T) {
+	tests := []struct {
+		tool Tool
+		want string
+	}{
+		{Tool{Name: "Go"}, `"Go"`},
+		{Tool{Name: "Go", Level: 4}, `{"name":"Go","level":4}`},
+		{Tool{Name: "Go", Years: 3, Featured: true}, `{"name":"Go","years":3,"featured":true}`},
+	}
+	for _, test := range tests {
+		b, err := json.Marshal(test.tool)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(b) != test.want {
+			t.Errorf("want %s, got %s", test.want, b)
+		}
+		var got Tool
+		if err := json.Unmarshal(b, &got); err != nil {
+			t.Fatal(err)
+		}
+		if got != test.tool {
+			t.Errorf("%s: want %+v after round-trip, got %+v", b, test.tool, got)
+		}
+	}
+}
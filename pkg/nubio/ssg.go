@@ -8,18 +8,10 @@ import (
 	"strings"
 )
 
-func RunSSG(args ...string) (exitcode int) {
+func RunSSG(configPath, outputDirpath string) (exitcode int) {
 	slogh := slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug})
 	logger := slog.New(slogh)
 
-	// Check arguments.
-	if len(args) < 2 {
-		logger.Error("missing arguments", "args", []string{"path to config.json", "path to output directory"})
-		return 1
-	}
-	configPath := args[0]
-	outputDirpath := args[1]
-
 	// Load conf.
 	conf, err := LoadResumeConfig(configPath)
 	if err != nil {
@@ -33,12 +25,21 @@ func RunSSG(args ...string) (exitcode int) {
 		}
 		return 1
 	}
+	for _, warn := range conf.Warnings() {
+		logger.Warn("resume config", "warning", warn)
+	}
+
+	if conf.EmailObfuscation == EmailObfuscationRedirect {
+		logger.Error("unsupported email obfuscation mode for static website", "mode", conf.EmailObfuscation)
+		return 1
+	}
 
-	// List export paths and corresponding function.
-	exports := map[string]ExportFunc{
-		"index.html":                            ExportHTML,
-		strings.TrimPrefix(PathResumePDF, "/"):  ExportPDF,
-		strings.TrimPrefix(PathResumeJSON, "/"): ExportJSON,
+	// List export paths and corresponding type.
+	exports := map[string]ExportType{
+		"index.html":                            ExportTypeHTML,
+		strings.TrimPrefix(PathResumePDF, "/"):  ExportTypePDF,
+		strings.TrimPrefix(PathResumeJSON, "/"): ExportTypeJSON,
+		strings.TrimPrefix(PathVCard, "/"):      ExportTypeVCard,
 	}
 
 	// Generate static files.
@@ -52,12 +53,19 @@ func RunSSG(args ...string) (exitcode int) {
 	if len(conf.PGPKey) > 0 {
 		files[strings.TrimPrefix(PathPGPKey, "/")] = []byte(conf.PGPKey)
 	}
+	if len(conf.Avatar) > 0 {
+		files[strings.TrimPrefix(conf.AvatarURLPath(), "/")] = conf.Avatar
+	}
 	if len(conf.CustomCSS) > 0 {
 		files[strings.TrimPrefix(PathCustomCSS, "/")] = []byte(conf.CustomCSS)
 	}
-	for path, export := range exports {
+	for path, b := range conf.Fonts.Files() {
+		files[strings.TrimPrefix(path, "/")] = b
+	}
+	opts := DefaultExportOptions()
+	for path, typ := range exports {
 		b := &bytes.Buffer{}
-		err = export(b, conf)
+		err = GetExporter(typ).Export(b, conf.ForExport(typ, AudiencePublic, opts), opts)
 		if err != nil {
 			logger.Error("export", "path", path, "error", err)
 			return 1
@@ -68,7 +76,12 @@ func RunSSG(args ...string) (exitcode int) {
 	// Write files.
 	for path, f := range files {
 		path = filepath.Join(outputDirpath, path)
-		err := os.WriteFile(path, f, 0666)
+		err := os.MkdirAll(filepath.Dir(path), 0777) // Note: Fonts are written in a subdirectory.
+		if err != nil {
+			logger.Error("create directory", "path", path, "error", err)
+			return 1
+		}
+		err = os.WriteFile(path, f, 0666)
 		if err != nil {
 			logger.Error("write file", "path", path, "error", err)
 			return 1
@@ -1,36 +1,101 @@
 package nubio
 
+import (
+	"slices"
+
+	"github.com/ejuju/nubio/pkg/mdlite"
+)
+
 // Holds the resume information that is actually public.
 // This type definition is needed for JSON exports,
 // to "select" which fields are exported.
+//
+// Note: Markdown-lite formatting and visibility are stripped from the fields.
+// Use ResumeConfig.ForExport beforehand to omit private fields.
 type ResumeExport struct {
 	Slug           string           `json:"slug"`
 	Name           string           `json:"name"`
+	Description    string           `json:"description"`
 	Domain         string           `json:"domain"`
 	EmailAddress   string           `json:"email_address"`
+	ContactDetails ContactDetails   `json:"contact_details"`
 	PGPKeyURL      string           `json:"pgp_key_url"`
 	Links          []Link           `json:"links"`
 	WorkExperience []WorkExperience `json:"work_experience"`
+	Projects       []Project        `json:"projects"`
 	Skills         []Skill          `json:"skills"`
 	Languages      []Language       `json:"languages"`
 	Education      []Education      `json:"education"`
+	Certifications []Certification  `json:"certifications"`
+	Publications   []Publication    `json:"publications"`
+	Talks          []Talk           `json:"talks"`
+	Awards         []Award          `json:"awards"`
 	Interests      []string         `json:"interests"`
 	Hobbies        []string         `json:"hobbies"`
+	CustomSections []CustomSection  `json:"custom_sections"`
 }
 
 func (conf *ResumeConfig) ToResumeExport() *ResumeExport {
+	// Strip formatting from rich text fields, limit highlights and strip visibility
+	// (without modifying the original config).
+	workExperience := slices.Clone(conf.WorkExperience)
+	for i := range workExperience {
+		workExperience[i].Visibility = ""
+		workExperience[i].Description = mdlite.ToPlainText(workExperience[i].Description)
+		workExperience[i].Highlights = limitHighlights(workExperience[i].Highlights, conf.MaxHighlights)
+	}
+	projects := slices.Clone(conf.Projects)
+	for i := range projects {
+		projects[i].Visibility = ""
+		projects[i].Description = mdlite.ToPlainText(projects[i].Description)
+		projects[i].Highlights = limitHighlights(projects[i].Highlights, conf.MaxHighlights)
+	}
+	education := slices.Clone(conf.Education)
+	for i := range education {
+		education[i].Visibility = ""
+		education[i].Highlights = limitHighlights(education[i].Highlights, conf.MaxHighlights)
+	}
+	customSections := slices.Clone(conf.CustomSections)
+	for i := range customSections {
+		customSections[i].Visibility = ""
+		customSections[i].Entries = slices.Clone(customSections[i].Entries)
+		for j := range customSections[i].Entries {
+			customSections[i].Entries[j].Visibility = ""
+			customSections[i].Entries[j].Links = withoutVisibility(customSections[i].Entries[j].Links, func(v *Link) *Visibility { return &v.Visibility })
+			customSections[i].Entries[j].Body = mdlite.ToPlainText(customSections[i].Entries[j].Body)
+		}
+	}
+
 	return &ResumeExport{
 		Slug:           conf.Slug,
 		Name:           conf.Name,
+		Description:    mdlite.ToPlainText(conf.Description),
 		Domain:         conf.Domain,
 		EmailAddress:   conf.EmailAddress,
+		ContactDetails: conf.ContactDetails.withoutVisibility(),
 		PGPKeyURL:      conf.PGPKeyURL,
-		Links:          conf.Links,
-		WorkExperience: conf.WorkExperience,
-		Skills:         conf.Skills,
-		Languages:      conf.Languages,
-		Education:      conf.Education,
+		Links:          withoutVisibility(conf.Links, func(v *Link) *Visibility { return &v.Visibility }),
+		WorkExperience: workExperience,
+		Projects:       projects,
+		Skills:         withoutVisibility(conf.Skills, func(v *Skill) *Visibility { return &v.Visibility }),
+		Languages:      withoutVisibility(conf.Languages, func(v *Language) *Visibility { return &v.Visibility }),
+		Education:      education,
+		Certifications: withoutVisibility(conf.Certifications, func(v *Certification) *Visibility { return &v.Visibility }),
+		Publications:   withoutVisibility(conf.Publications, func(v *Publication) *Visibility { return &v.Visibility }),
+		Talks:          withoutVisibility(conf.Talks, func(v *Talk) *Visibility { return &v.Visibility }),
+		Awards:         withoutVisibility(conf.Awards, func(v *Award) *Visibility { return &v.Visibility }),
 		Interests:      conf.Interests,
 		Hobbies:        conf.Hobbies,
+		CustomSections: customSections,
+	}
+}
+
+// Returns a copy of the given entries with an empty visibility
+// (only used to filter entries, not exported).
+func withoutVisibility[T any](entries []T, visibility func(*T) *Visibility) []T {
+	entries = slices.Clone(entries)
+	for i := range entries {
+		*visibility(&entries[i]) = ""
 	}
+	return entries
 }
@@ -0,0 +1,80 @@
+package nubio
+
+import (
+	"io"
+	"strings"
+
+	"github.com/ejuju/nubio/pkg/mdlite"
+)
+
+// Writes the resume contact information as a vCard (version 4.0, RFC 6350).
+func ExportVCard(w io.Writer, conf *ResumeConfig, _ ExportOptions) error {
+	lines := []string{
+		"BEGIN:VCARD",
+		"VERSION:4.0",
+		"KIND:individual",
+		"FN:" + escapeVCardText(conf.Name),
+	}
+	if conf.Description != "" {
+		lines = append(lines, "TITLE:"+escapeVCardText(mdlite.ToPlainText(conf.Description)))
+	}
+	if conf.EmailAddress != "" {
+		lines = append(lines, "EMAIL;PREF=1:"+escapeVCardText(conf.EmailAddress))
+	}
+
+	contact := conf.ContactDetails
+	for _, v := range contact.Phones {
+		line := "TEL;VALUE=uri"
+		if contact.PreferredMethod.Value == ContactMethodPhone {
+			line += ";PREF=1"
+		}
+		lines = append(lines, line+":tel:"+v.Number)
+	}
+	if contact.Location.City != "" || contact.Location.Country != "" {
+		lines = append(lines, "ADR:;;;"+escapeVCardText(contact.Location.City)+";;;"+escapeVCardText(contact.Location.Country))
+	}
+	if contact.TimeZone.Value != "" {
+		lines = append(lines, "TZ:"+escapeVCardText(contact.TimeZone.Value))
+	}
+	if contact.Availability.Value != "" {
+		lines = append(lines, "NOTE:"+escapeVCardText(contact.Availability.Value))
+	}
+
+	lines = append(lines, "URL:https://"+conf.Domain)
+	for _, v := range conf.Links {
+		if kind := detectLinkKind(v.URL); kind == LinkKindEmail || kind == LinkKindPhone {
+			continue // Note: Email address and phone numbers are written in EMAIL and TEL lines.
+		}
+		lines = append(lines, "URL:"+v.URL)
+	}
+	lines = append(lines, "END:VCARD")
+
+	for _, line := range lines {
+		_, err := io.WriteString(w, foldVCardLine(line)+"\r\n")
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var vcardTextEscaper = strings.NewReplacer(`\`, `\\`, ",", `\,`, ";", `\;`, "\r\n", `\n`, "\n", `\n`)
+
+func escapeVCardText(v string) string { return vcardTextEscaper.Replace(v) }
+
+// Splits lines longer than 75 octets (without splitting UTF-8 characters).
+func foldVCardLine(line string) string {
+	const maxLineSize = 75
+	b := &strings.Builder{}
+	size := 0
+	for _, c := range line {
+		n := len(string(c))
+		if size+n > maxLineSize {
+			b.WriteString("\r\n ")
+			size = 1
+		}
+		b.WriteRune(c)
+		size += n
+	}
+	return b.String()
+}
@@ -0,0 +1,99 @@
+package nubio
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Controls where a field (or entry) is rendered.
+type Visibility string
+
+const (
+	VisibilityPublic  Visibility = "public"   // Rendered in all exports (default).
+	VisibilityPrivate Visibility = "private"  // Only rendered for the private audience.
+	VisibilityPDFOnly Visibility = "pdf-only" // Only rendered in PDF exports for the private audience.
+)
+
+func (v Visibility) Check() error {
+	switch v {
+	case "", VisibilityPublic, VisibilityPrivate, VisibilityPDFOnly:
+		return nil
+	}
+	return fmt.Errorf("unknown visibility: %q", v)
+}
+
+// Reports whether a field with this visibility is rendered for the given export type and audience.
+func (v Visibility) IsVisibleIn(typ ExportType, audience Audience) bool {
+	switch v {
+	case VisibilityPrivate:
+		return audience == AudiencePrivate
+	case VisibilityPDFOnly:
+		return typ == ExportTypePDF && audience == AudiencePrivate // Note: The public PDF is available on the website.
+	}
+	return true
+}
+
+// Indicates who an export is meant for.
+type Audience string
+
+const (
+	AudiencePublic  Audience = "public"  // Website visitors and public files (default).
+	AudiencePrivate Audience = "private" // Direct recipients (ex: when applying for a job).
+)
+
+// Returns a copy of the resume config without the fields and entries
+// that are not rendered for the given export type and audience.
+//
+// Expired certifications are checked against the export time (see ExportOptions.Time).
+//
+// Note: Exporters render all fields of the config they're given,
+// this method should be called beforehand.
+func (conf *ResumeConfig) ForExport(typ ExportType, audience Audience, opts ExportOptions) *ResumeConfig {
+	out := *conf
+	if conf.EmailObfuscation != EmailObfuscationNone && audience == AudiencePublic &&
+		(typ == ExportTypeJSON || typ == ExportTypeVCard) {
+		out.EmailAddress = "" // Protect obfuscated email address from scrapers.
+	}
+	out.ContactDetails = conf.ContactDetails.visibleIn(typ, audience)
+	out.Links = filterVisible(conf.Links, typ, audience, func(v Link) Visibility { return v.Visibility })
+	out.WorkExperience = filterVisible(conf.WorkExperience, typ, audience, func(v WorkExperience) Visibility { return v.Visibility })
+	out.Projects = filterVisible(conf.Projects, typ, audience, func(v Project) Visibility { return v.Visibility })
+	out.Skills = filterVisible(conf.Skills, typ, audience, func(v Skill) Visibility { return v.Visibility })
+	out.Languages = filterVisible(conf.Languages, typ, audience, func(v Language) Visibility { return v.Visibility })
+	out.Education = filterVisible(conf.Education, typ, audience, func(v Education) Visibility { return v.Visibility })
+	out.Certifications = filterVisible(conf.Certifications, typ, audience, func(v Certification) Visibility { return v.Visibility })
+	if conf.HideExpiredCertifications {
+		now := opts.time()
+		out.Certifications = slices.DeleteFunc(out.Certifications, func(v Certification) bool { return v.IsExpired(now) })
+	}
+	out.Publications = filterVisible(conf.Publications, typ, audience, func(v Publication) Visibility { return v.Visibility })
+	out.Talks = filterVisible(conf.Talks, typ, audience, func(v Talk) Visibility { return v.Visibility })
+	out.Awards = filterVisible(conf.Awards, typ, audience, func(v Award) Visibility { return v.Visibility })
+	out.CustomSections = filterVisible(conf.CustomSections, typ, audience, func(v CustomSection) Visibility { return v.Visibility })
+	for i, section := range out.CustomSections {
+		out.CustomSections[i].Entries = filterVisible(section.Entries, typ, audience, func(v CustomSectionEntry) Visibility { return v.Visibility })
+		for j, entry := range out.CustomSections[i].Entries {
+			out.CustomSections[i].Entries[j].Links = filterVisible(entry.Links, typ, audience, func(v Link) Visibility { return v.Visibility })
+		}
+	}
+
+	// Omit email links if the email address is obfuscated (they would reveal it).
+	// Note: Like the email address, they are kept in the PDF export.
+	if conf.EmailObfuscation != EmailObfuscationNone && audience == AudiencePublic && typ != ExportTypePDF {
+		isEmail := func(v Link) bool { return detectLinkKind(v.URL) == LinkKindEmail }
+		out.Links = slices.DeleteFunc(out.Links, isEmail)
+		for i := range out.CustomSections {
+			for j := range out.CustomSections[i].Entries {
+				out.CustomSections[i].Entries[j].Links = slices.DeleteFunc(out.CustomSections[i].Entries[j].Links, isEmail)
+			}
+		}
+	}
+	return &out
+}
+
+// Returns a copy of the given entries without the ones that are not visible.
+func filterVisible[T any](entries []T, typ ExportType, audience Audience, visibility func(T) Visibility) []T {
+	return slices.DeleteFunc(slices.Clone(entries), func(v T) bool {
+		return !visibility(v).IsVisibleIn(typ, audience)
+	})
+}
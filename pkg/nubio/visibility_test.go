@@ -0,0 +1,126 @@
+package nubio
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestForExport(t *testing.T) {
+	conf := &ResumeConfig{
+		Name: "Jane Doe",
+		Links: []Link{
+			{Label: "Public", URL: "https://example.com/public"},
+			{Label: "Private", URL: "https://example.com/private", Visibility: VisibilityPrivate},
+			{Label: "PDF only", URL: "https://example.com/pdf", Visibility: VisibilityPDFOnly},
+		},
+	}
+	labels := func(links []Link) string {
+		var s []string
+		for _, link := range links {
+			s = append(s, link.Label)
+		}
+		return strings.Join(s, ",")
+	}
+	tests := []struct {
+		typ      ExportType
+		audience Audience
+		want     string
+	}{
+		{ExportTypeHTML, AudiencePublic, "Public"},
+		{ExportTypePDF, AudiencePublic, "Public"},
+		{ExportTypeHTML, AudiencePrivate, "Public,Private"},
+		{ExportTypePDF, AudiencePrivate, "Public,Private,PDF only"},
+	}
+	for _, test := range tests {
+		got := labels(conf.ForExport(test.typ, test.audience, ExportOptions{}).Links)
+		if got != test.want {
+			t.Errorf("%s (%s): want links %q, got %q", test.typ, test.audience, test.want, got)
+		}
+	}
+	if len(conf.Links) != 3 {
+		t.Fatalf("original config was modified")
+	}
+}
+
+func TestExportJSONOmitsVisibility(t *testing.T) {
+	conf := &ResumeConfig{
+		Name:  "Jane Doe",
+		Links: []Link{{Label: "Public", URL: "https://example.com", Visibility: VisibilityPublic}},
+		ContactDetails: ContactDetails{
+			Location: ContactLocation{City: "Paris", Visibility: VisibilityPublic},
+		},
+	}
+	b := &bytes.Buffer{}
+	err := ExportJSON(b, conf.ForExport(ExportTypeJSON, AudiencePublic, ExportOptions{}), ExportOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(b.String(), "visibility") {
+		t.Fatalf("JSON export contains visibility: %s", b)
+	}
+	if conf.Links[0].Visibility != VisibilityPublic {
+		t.Fatalf("original config was modified")
+	}
+}
+
+func TestForExportCustomSectionLinks(t *testing.T) {
+	conf := &ResumeConfig{
+		Name: "Jane Doe",
+		CustomSections: []CustomSection{{
+			Heading: "Volunteering",
+			Entries: []CustomSectionEntry{{
+				Title: "Mentor",
+				Links: []Link{
+					{Label: "Public", URL: "https://example.com/public", Visibility: VisibilityPublic},
+					{Label: "Private", URL: "https://example.com/private", Visibility: VisibilityPrivate},
+				},
+			}},
+		}},
+	}
+	for _, typ := range []ExportType{ExportTypeHTML, ExportTypeJSON} {
+		b := &bytes.Buffer{}
+		err := GetExporter(typ).Export(b, conf.ForExport(typ, AudiencePublic, ExportOptions{}), ExportOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(b.String(), "example.com/public") || strings.Contains(b.String(), "example.com/private") {
+			t.Errorf("%s: want only public link, got: %s", typ, b)
+		}
+		if strings.Contains(b.String(), `"visibility"`) {
+			t.Errorf("%s: export contains visibility: %s", typ, b)
+		}
+	}
+	if len(conf.CustomSections[0].Entries[0].Links) != 2 {
+		t.Fatalf("original config was modified")
+	}
+}
+
+func TestForExportObfuscatedEmailLinks(t *testing.T) {
+	conf := &ResumeConfig{
+		Name:             "Jane Doe",
+		EmailAddress:     "jane@example.com",
+		EmailObfuscation: EmailObfuscationEntities,
+		Links: []Link{
+			{Label: "Email", URL: "mailto:jane@example.com"},
+			{Label: "Website", URL: "https://example.com"},
+		},
+		CustomSections: []CustomSection{{
+			Heading: "Volunteering",
+			Entries: []CustomSectionEntry{{Title: "Mentor", Links: []Link{{Label: "Email", URL: "mailto:jane@example.com"}}}},
+		}},
+	}
+	for _, typ := range []ExportType{ExportTypeHTML, ExportTypeJSON, ExportTypeVCard} {
+		b := &bytes.Buffer{}
+		err := GetExporter(typ).Export(b, conf.ForExport(typ, AudiencePublic, ExportOptions{}), ExportOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if strings.Contains(b.String(), "jane@example.com") || !strings.Contains(b.String(), "https://example.com") {
+			t.Errorf("%s: want email links omitted, got: %s", typ, b)
+		}
+	}
+	if got := conf.ForExport(ExportTypeHTML, AudiencePrivate, ExportOptions{}).Links; len(got) != 2 {
+		t.Errorf("want email links in private exports, got %+v", got)
+	}
+}